@@ -0,0 +1,167 @@
+package bamboo
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// accessTokensBase is relative to the REST API base URL as tokens are managed by a separate plugin
+const accessTokensBase = "../../access-tokens/1.0/user"
+
+// AccessTokenService handles communication with the personal access token methods of the authenticated user
+type AccessTokenService service
+
+// AccessToken is a personal access token of the authenticated user. Timestamps are converted from
+// the millisecond epoch values the API returns. Token is only set on a newly created token.
+type AccessToken struct {
+	ID             string       `json:"id"`
+	Name           string       `json:"name"`
+	Permissions    []Permission `json:"permissions,omitempty"`
+	CreatedAt      time.Time    `json:"createdAt"`
+	ExpiringAt     time.Time    `json:"expiringAt"`
+	LastAccessedAt time.Time    `json:"lastAccessedAt"`
+	Token          string       `json:"token,omitempty"`
+}
+
+// UnmarshalJSON decodes an access token, converting its epoch timestamps to time.Time
+func (t *AccessToken) UnmarshalJSON(data []byte) error {
+	type alias AccessToken
+	aux := struct {
+		*alias
+		CreatedAt      epochTime `json:"createdAt"`
+		ExpiringAt     epochTime `json:"expiringAt"`
+		LastAccessedAt epochTime `json:"lastAccessedAt"`
+	}{alias: (*alias)(t)}
+
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	t.CreatedAt = aux.CreatedAt.Time
+	t.ExpiringAt = aux.ExpiringAt.Time
+	t.LastAccessedAt = aux.LastAccessedAt.Time
+	return nil
+}
+
+// Expired reports whether the token has an expiry date that has passed
+func (t *AccessToken) Expired() bool {
+	return !t.ExpiringAt.IsZero() && time.Now().After(t.ExpiringAt)
+}
+
+// AccessTokenOptions specifies the parameters of a new personal access token
+// - Name:        Name shown for the token, required
+// - Permissions: What the token may do, e.g. ReadPermission or AdminPermission. The server default is used if empty
+// - ExpiryDays:  Number of days the token is valid for. Zero creates a token that never expires
+type AccessTokenOptions struct {
+	Name        string       `json:"name"`
+	Permissions []Permission `json:"permissions,omitempty"`
+	ExpiryDays  int          `json:"expiryDays,omitempty"`
+}
+
+// ListAccessTokens returns the personal access tokens of the authenticated user
+func (a *AccessTokenService) ListAccessTokens() ([]*AccessToken, *http.Response, error) {
+	request, err := a.client.NewRequest(http.MethodGet, accessTokensBase, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	tokens := []*AccessToken{}
+	response, err := a.client.Do(request, &tokens)
+	if err != nil {
+		return nil, response, err
+	}
+
+	if response.StatusCode == 401 {
+		return nil, response, &simpleError{"You must be logged in to manage access tokens"}
+	} else if response.StatusCode != 200 {
+		return nil, response, &simpleError{fmt.Sprintf("Listing access tokens returned %s", response.Status)}
+	}
+
+	return tokens, response, nil
+}
+
+// CreateAccessToken creates a personal access token for the authenticated user. The returned
+// token's Token field holds the secret, which can't be retrieved again.
+func (a *AccessTokenService) CreateAccessToken(opts *AccessTokenOptions) (*AccessToken, *http.Response, error) {
+	if opts == nil || opts.Name == "" {
+		return nil, nil, &simpleError{"An access token needs a name"}
+	}
+	if opts.ExpiryDays < 0 {
+		return nil, nil, &simpleError{"Access token expiry can't be negative"}
+	}
+
+	request, err := a.client.NewRequest(http.MethodPut, accessTokensBase, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	token := &AccessToken{}
+	response, err := a.client.Do(request, token)
+	if err != nil {
+		return nil, response, err
+	}
+
+	switch response.StatusCode {
+	case 200, 201:
+		return token, response, nil
+	case 400:
+		return nil, response, &simpleError{fmt.Sprintf("Access token %s already exists or its options are invalid", opts.Name)}
+	case 401:
+		return nil, response, &simpleError{"You must be logged in to manage access tokens"}
+	}
+	return nil, response, &simpleError{fmt.Sprintf("Server responded with unexpected return code %d", response.StatusCode)}
+}
+
+// RevokeAccessToken revokes the personal access token with the given id
+func (a *AccessTokenService) RevokeAccessToken(id string) (*http.Response, error) {
+	if id == "" {
+		return nil, &simpleError{"An access token id is required"}
+	}
+
+	request, err := a.client.NewRequest(http.MethodDelete, fmt.Sprintf(accessTokensBase+"/%s", id), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := a.client.Do(request, nil)
+	if err != nil {
+		return response, err
+	}
+
+	switch response.StatusCode {
+	case 200, 204:
+		return response, nil
+	case 401:
+		return response, &simpleError{"You must be logged in to manage access tokens"}
+	case 404:
+		return response, &simpleError{fmt.Sprintf("Access token %s doesn't exist", id)}
+	}
+	return response, &simpleError{fmt.Sprintf("Server responded with unexpected return code %d", response.StatusCode)}
+}
+
+// RotateAccessToken creates a new personal access token and passes it to persist, which should store the
+// secret and may install it with SetAuthorizer(&TokenCredentials{Token: token.Token}). The token with id
+// oldID is only revoked once persist succeeds, so a failure never leaves the caller without a working
+// token. The new token is returned along with any error from persist or from revoking the old token.
+func (a *AccessTokenService) RotateAccessToken(oldID string, opts *AccessTokenOptions, persist func(*AccessToken) error) (*AccessToken, error) {
+	if persist == nil {
+		return nil, &simpleError{"Rotating an access token needs somewhere to persist the new one"}
+	}
+
+	token, _, err := a.CreateAccessToken(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := persist(token); err != nil {
+		return token, &simpleError{fmt.Sprintf("Persisting access token %s failed, %s was not revoked: %s", token.Name, oldID, err)}
+	}
+
+	if oldID == "" {
+		return token, nil
+	}
+	_, err = a.RevokeAccessToken(oldID)
+	return token, err
+}
@@ -0,0 +1,72 @@
+package bamboo_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	bamboo "github.com/rcarmstrong/go-bamboo"
+)
+
+func TestAccessTokens(t *testing.T) {
+	var revoked []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/rest/access-tokens/1.0/user" && r.Method == http.MethodGet:
+			expired := time.Now().Add(-time.Hour).UnixNano() / int64(time.Millisecond)
+			fmt.Fprintf(w, `[{"id":"100","name":"ci","createdAt":1546300800000},{"id":"101","name":"old","expiringAt":%d}]`, expired)
+		case r.URL.Path == "/rest/access-tokens/1.0/user" && r.Method == http.MethodPut:
+			opts := &bamboo.AccessTokenOptions{}
+			json.NewDecoder(r.Body).Decode(opts)
+			fmt.Fprintf(w, `{"id":"102","name":"%s","token":"s3cret"}`, opts.Name)
+		case r.URL.Path == "/rest/access-tokens/1.0/user/100" && r.Method == http.MethodDelete:
+			revoked = append(revoked, r.Header.Get("Authorization"))
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	client := bamboo.NewTokenClient(nil, "original")
+	client.SetURL(ts.URL)
+
+	tokens, _, err := client.AccessTokens.ListAccessTokens()
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(tokens))
+	assert.Equal(t, 2019, tokens[0].CreatedAt.UTC().Year())
+	assert.False(t, tokens[0].Expired())
+	assert.True(t, tokens[1].Expired())
+
+	_, _, err = client.AccessTokens.CreateAccessToken(&bamboo.AccessTokenOptions{})
+	assert.Error(t, err)
+
+	// The old token is kept when the new one can't be stored
+	token, err := client.AccessTokens.RotateAccessToken("100", &bamboo.AccessTokenOptions{Name: "ci-2"}, func(*bamboo.AccessToken) error {
+		return fmt.Errorf("vault is sealed")
+	})
+	assert.Error(t, err)
+	assert.Equal(t, "s3cret", token.Token)
+	assert.Empty(t, revoked)
+
+	var stored string
+	token, err = client.AccessTokens.RotateAccessToken("100", &bamboo.AccessTokenOptions{Name: "ci-2", ExpiryDays: 90}, func(token *bamboo.AccessToken) error {
+		stored = token.Token
+		client.SetAuthorizer(&bamboo.TokenCredentials{Token: token.Token})
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "ci-2", token.Name)
+	assert.Equal(t, "s3cret", stored)
+
+	// The old token is revoked after the new one was installed
+	assert.Equal(t, []string{"Bearer s3cret"}, revoked)
+
+	_, err = client.AccessTokens.RevokeAccessToken("999")
+	assert.Error(t, err)
+}
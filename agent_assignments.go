@@ -0,0 +1,194 @@
+package bamboo
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+)
+
+// ProjectAssignment dedicates an agent to every plan of a project
+const ProjectAssignment string = "PROJECT"
+
+// PlanAssignment dedicates an agent to a plan
+const PlanAssignment string = "PLAN"
+
+// JobAssignment dedicates an agent to a single job of a plan
+const JobAssignment string = "JOB"
+
+// DeploymentProjectAssignment dedicates an agent to every environment of a deployment project
+const DeploymentProjectAssignment string = "DEPLOYMENT_PROJECT"
+
+// EnvironmentAssignment dedicates an agent to a deployment environment
+const EnvironmentAssignment string = "ENVIRONMENT"
+
+var knownAssignments = map[string]bool{
+	ProjectAssignment:           true,
+	PlanAssignment:              true,
+	JobAssignment:               true,
+	DeploymentProjectAssignment: true,
+	EnvironmentAssignment:       true,
+}
+
+// AgentAssignment dedicates an agent to a project, plan, job, deployment project or environment
+// - Type:     One of the *Assignment constants
+// - EntityID: Id of the project, plan, job, deployment project or environment
+// - Title:    Name of the entity, as returned by the server
+type AgentAssignment struct {
+	AgentID  int    `json:"executorId"`
+	Type     string `json:"executableType"`
+	EntityID int    `json:"executableId"`
+	Title    string `json:"executableTitle,omitempty"`
+}
+
+func (a *AgentAssignment) String() string {
+	if a.Title != "" {
+		return fmt.Sprintf("%s %d (%s)", a.Type, a.EntityID, a.Title)
+	}
+	return fmt.Sprintf("%s %d", a.Type, a.EntityID)
+}
+
+// AgentAssignments returns everything the agent is dedicated to. An agent without assignments can run any job.
+func (a *AgentService) AgentAssignments(agentID int) ([]*AgentAssignment, *http.Response, error) {
+	request, err := a.client.NewRequest(http.MethodGet, agentAssignmentURL(agentID, nil), nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	assignments := []*AgentAssignment{}
+	response, err := a.client.Do(request, &assignments)
+	if err != nil {
+		return nil, response, err
+	}
+
+	if response.StatusCode == 401 {
+		return nil, response, &simpleError{"You must be an admin to access this information"}
+	} else if response.StatusCode != 200 {
+		return nil, response, &simpleError{fmt.Sprintf("Retrieving assignments of agent %d returned %s", agentID, response.Status)}
+	}
+
+	for _, assignment := range assignments {
+		assignment.AgentID = agentID
+	}
+	return assignments, response, nil
+}
+
+// AssignAgent dedicates the agent to the entity of the given assignment type
+func (a *AgentService) AssignAgent(agentID int, assignmentType string, entityID int) (*http.Response, error) {
+	return a.changeAssignment(http.MethodPost, &AgentAssignment{AgentID: agentID, Type: assignmentType, EntityID: entityID})
+}
+
+// UnassignAgent removes the agent's dedication to the entity of the given assignment type
+func (a *AgentService) UnassignAgent(agentID int, assignmentType string, entityID int) (*http.Response, error) {
+	return a.changeAssignment(http.MethodDelete, &AgentAssignment{AgentID: agentID, Type: assignmentType, EntityID: entityID})
+}
+
+func (a *AgentService) changeAssignment(method string, assignment *AgentAssignment) (*http.Response, error) {
+	if !knownAssignments[assignment.Type] {
+		return nil, &simpleError{fmt.Sprintf("Unknown assignment type %s", assignment.Type)}
+	}
+
+	request, err := a.client.NewRequest(method, agentAssignmentURL(assignment.AgentID, assignment), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := a.client.Do(request, nil)
+	if err != nil {
+		return response, err
+	}
+
+	switch response.StatusCode {
+	case 200, 204:
+		return response, nil
+	case 400:
+		return response, &simpleError{fmt.Sprintf("Agent %d or %s doesn't exist", assignment.AgentID, assignment)}
+	case 401:
+		return response, &simpleError{"You must be an admin to preform this action"}
+	}
+	return response, &simpleError{fmt.Sprintf("Server responded with unexpected return code %d", response.StatusCode)}
+}
+
+// SetAgentAssignments changes the agent's assignments to exactly the desired ones. Only the differences
+// are applied, and the ones that were applied are returned. If a change fails the changes made before it
+// are returned along with the error. Passing no assignments makes the agent available to every job again.
+func (a *AgentService) SetAgentAssignments(agentID int, desired []*AgentAssignment) (added, removed []*AgentAssignment, err error) {
+	for _, assignment := range desired {
+		if !knownAssignments[assignment.Type] {
+			return nil, nil, &simpleError{fmt.Sprintf("Unknown assignment type %s", assignment.Type)}
+		}
+	}
+
+	current, _, err := a.AgentAssignments(agentID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	key := func(assignment *AgentAssignment) string {
+		return assignment.Type + "/" + strconv.Itoa(assignment.EntityID)
+	}
+
+	var toAdd, toRemove []*AgentAssignment
+	have := map[string]bool{}
+	for _, assignment := range current {
+		have[key(assignment)] = true
+	}
+	want := map[string]bool{}
+	for _, assignment := range desired {
+		want[key(assignment)] = true
+		if !have[key(assignment)] {
+			toAdd = append(toAdd, &AgentAssignment{AgentID: agentID, Type: assignment.Type, EntityID: assignment.EntityID, Title: assignment.Title})
+			have[key(assignment)] = true
+		}
+	}
+	for _, assignment := range current {
+		if !want[key(assignment)] {
+			toRemove = append(toRemove, assignment)
+		}
+	}
+
+	for _, assignment := range toAdd {
+		if _, err := a.changeAssignment(http.MethodPost, assignment); err != nil {
+			return added, removed, err
+		}
+		added = append(added, assignment)
+	}
+	for _, assignment := range toRemove {
+		if _, err := a.changeAssignment(http.MethodDelete, assignment); err != nil {
+			return added, removed, err
+		}
+		removed = append(removed, assignment)
+	}
+
+	return added, removed, nil
+}
+
+// AllAgentAssignments returns the assignments of every agent picked by opts, keyed by agent id.
+// Agents that aren't dedicated to anything are included with no assignments.
+func (a *AgentService) AllAgentAssignments(opts *AgentListOptions) (map[int][]*AgentAssignment, error) {
+	listOpts := AgentListOptions{}
+	if opts != nil {
+		listOpts = *opts
+	}
+	listOpts.Details = false
+
+	agents, _, err := a.ListAgents(&listOpts)
+	if err != nil {
+		return nil, err
+	}
+
+	collected := make([][]*AgentAssignment, len(agents))
+	err = forEach(len(agents), listOpts.Concurrency, func(i int) error {
+		assignments, _, err := a.AgentAssignments(agents[i].ID)
+		collected[i] = assignments
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	layout := map[int][]*AgentAssignment{}
+	for i, agent := range agents {
+		layout[agent.ID] = collected[i]
+	}
+	return layout, nil
+}
@@ -0,0 +1,111 @@
+package bamboo_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	bamboo "github.com/rcarmstrong/go-bamboo"
+)
+
+// assignmentsStub serves the assignments of two agents and records every assignment change
+type assignmentsStub struct {
+	mu    sync.Mutex
+	calls []string
+	fail  string // entityId whose changes are rejected
+}
+
+func (s *assignmentsStub) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	path := strings.TrimPrefix(r.URL.Path, "/rest/api/latest/")
+	query := r.URL.Query()
+
+	switch {
+	case path == "agent":
+		fmt.Fprint(w, `[{"id":1,"name":"local","type":"LOCAL","enabled":true},{"id":2,"name":"remote","type":"REMOTE","enabled":true}]`)
+	case path == "agent/assignment" && query.Get("executorType") != "AGENT":
+		w.WriteHeader(http.StatusBadRequest)
+	case path == "agent/assignment" && r.Method == http.MethodGet:
+		if query.Get("executorId") == "1" {
+			fmt.Fprint(w, `[{"executableType":"PROJECT","executableId":10,"executableTitle":"Core"},{"executableType":"ENVIRONMENT","executableId":20,"executableTitle":"Core - prod"}]`)
+			return
+		}
+		fmt.Fprint(w, `[]`)
+	case path == "agent/assignment" && s.fail != "" && query.Get("entityId") == s.fail:
+		w.WriteHeader(http.StatusBadRequest)
+	case path == "agent/assignment":
+		s.mu.Lock()
+		s.calls = append(s.calls, fmt.Sprintf("%s %s %s %s", r.Method, query.Get("executorId"), query.Get("assignmentType"), query.Get("entityId")))
+		s.mu.Unlock()
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func TestAgentAssignments(t *testing.T) {
+	stub := &assignmentsStub{}
+	ts := httptest.NewServer(stub)
+	defer ts.Close()
+
+	client := bamboo.NewSimpleClient(nil, "", "")
+	client.SetURL(ts.URL)
+
+	assignments, _, err := client.Agents.AgentAssignments(1)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(assignments))
+	assert.Equal(t, "PROJECT 10 (Core)", assignments[0].String())
+	assert.Equal(t, 1, assignments[0].AgentID)
+
+	layout, err := client.Agents.AllAgentAssignments(nil)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(layout[1]))
+	assert.Empty(t, layout[2])
+
+	_, err = client.Agents.AssignAgent(2, bamboo.PlanAssignment, 30)
+	assert.NoError(t, err)
+	_, err = client.Agents.UnassignAgent(2, bamboo.PlanAssignment, 30)
+	assert.NoError(t, err)
+	_, err = client.Agents.AssignAgent(2, "BRANCH", 30)
+	assert.Error(t, err)
+	assert.Equal(t, []string{"POST 2 PLAN 30", "DELETE 2 PLAN 30"}, stub.calls)
+}
+
+func TestSetAgentAssignments(t *testing.T) {
+	stub := &assignmentsStub{}
+	ts := httptest.NewServer(stub)
+	defer ts.Close()
+
+	client := bamboo.NewSimpleClient(nil, "", "")
+	client.SetURL(ts.URL)
+
+	added, removed, err := client.Agents.SetAgentAssignments(1, []*bamboo.AgentAssignment{
+		{Type: bamboo.ProjectAssignment, EntityID: 10},
+		{Type: bamboo.DeploymentProjectAssignment, EntityID: 3},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(added))
+	assert.Equal(t, 1, len(removed))
+	assert.Equal(t, []string{"POST 1 DEPLOYMENT_PROJECT 3", "DELETE 1 ENVIRONMENT 20"}, stub.calls)
+
+	_, _, err = client.Agents.SetAgentAssignments(1, []*bamboo.AgentAssignment{{Type: "BRANCH", EntityID: 1}})
+	assert.Error(t, err)
+	assert.Equal(t, 2, len(stub.calls))
+
+	// A failure part way through reports what was already changed
+	stub.calls = nil
+	stub.fail = "20"
+	added, removed, err = client.Agents.SetAgentAssignments(1, []*bamboo.AgentAssignment{
+		{Type: bamboo.PlanAssignment, EntityID: 30},
+	})
+	assert.Error(t, err)
+	assert.Equal(t, 1, len(added))
+	assert.Equal(t, 30, added[0].EntityID)
+	assert.Equal(t, 1, len(removed))
+	assert.Equal(t, 10, removed[0].EntityID)
+	assert.Equal(t, []string{"POST 1 PLAN 30", "DELETE 1 PROJECT 10"}, stub.calls)
+}
@@ -0,0 +1,127 @@
+package bamboo
+
+// CanRun reports whether the agent's capabilities meet every one of the requirements.
+// The agent's capabilities must have been fetched, see AgentListOptions.Details.
+func (a *Agent) CanRun(requirements []*Requirement) bool {
+	for _, requirement := range requirements {
+		if !requirement.SatisfiedBy(a.Capabilities) {
+			return false
+		}
+	}
+	return true
+}
+
+// JobMatch is a job along with the agents capable of running it
+// - Unmet: Requirements no agent satisfies on its own, which usually explains an empty Agents
+type JobMatch struct {
+	Job          *PlanJob
+	Requirements []*Requirement
+	Agents       []*Agent
+	Unmet        []*Requirement
+}
+
+// AgentMatchReport is the result of matching the jobs of one or more plans against the agents
+type AgentMatchReport struct {
+	Jobs []*JobMatch
+}
+
+// Unrunnable returns the jobs that no agent is capable of running
+func (r *AgentMatchReport) Unrunnable() []*JobMatch {
+	unrunnable := []*JobMatch{}
+	for _, match := range r.Jobs {
+		if len(match.Agents) == 0 {
+			unrunnable = append(unrunnable, match)
+		}
+	}
+	return unrunnable
+}
+
+// AgentJobs indexes the matched jobs by the id of every agent that can run them
+func (r *AgentMatchReport) AgentJobs() map[int][]*PlanJob {
+	index := map[int][]*PlanJob{}
+	for _, match := range r.Jobs {
+		for _, agent := range match.Agents {
+			index[agent.ID] = append(index[agent.ID], match.Job)
+		}
+	}
+	return index
+}
+
+// MatchAgents works out which agents can run each job of the given plans. Disabled agents are never
+// considered capable. opts selects the agents to match against; details are always fetched.
+func (a *AgentService) MatchAgents(planKeys []string, opts *AgentListOptions) (*AgentMatchReport, error) {
+	listOpts := AgentListOptions{}
+	if opts != nil {
+		listOpts = *opts
+	}
+	listOpts.Details = true
+
+	agents, _, err := a.ListAgents(&listOpts)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &AgentMatchReport{}
+	for _, planKey := range planKeys {
+		jobs, _, err := a.client.Plans.PlanJobs(planKey)
+		if err != nil {
+			return nil, err
+		}
+		for _, job := range jobs {
+			report.Jobs = append(report.Jobs, &JobMatch{Job: job})
+		}
+	}
+
+	err = forEach(len(report.Jobs), listOpts.Concurrency, func(i int) error {
+		match := report.Jobs[i]
+		requirements, _, err := a.client.Plans.JobRequirements(match.Job.Key)
+		if err != nil {
+			return err
+		}
+		match.Requirements = requirements
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, match := range report.Jobs {
+		match.Agents, match.Unmet = matchJob(match.Requirements, agents)
+	}
+
+	return report, nil
+}
+
+// matchJob returns the enabled agents that can run a job with the given requirements,
+// and the requirements none of the enabled agents satisfy
+func matchJob(requirements []*Requirement, agents []*Agent) (capable []*Agent, unmet []*Requirement) {
+	enabled := []*Agent{}
+	for _, agent := range agents {
+		if agent.Enabled {
+			enabled = append(enabled, agent)
+		}
+	}
+
+	capable = []*Agent{}
+	for _, agent := range enabled {
+		if agent.CanRun(requirements) {
+			capable = append(capable, agent)
+		}
+	}
+
+	unmet = []*Requirement{}
+	for _, requirement := range requirements {
+		satisfied := false
+		for _, agent := range enabled {
+			if requirement.SatisfiedBy(agent.Capabilities) {
+				satisfied = true
+				break
+			}
+		}
+		if !satisfied {
+			unmet = append(unmet, requirement)
+		}
+	}
+
+	return capable, unmet
+}
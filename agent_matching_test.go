@@ -0,0 +1,87 @@
+package bamboo_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	bamboo "github.com/rcarmstrong/go-bamboo"
+)
+
+func TestRequirementSatisfiedBy(t *testing.T) {
+	capabilities := []*bamboo.Capability{{Key: "system.jdk.JDK 11", Value: "/opt/jdk-11"}}
+
+	assert.True(t, (&bamboo.Requirement{Key: "system.jdk.JDK 11", MatchType: bamboo.RequirementExists}).SatisfiedBy(capabilities))
+	assert.True(t, (&bamboo.Requirement{Key: "system.jdk.JDK 11", MatchType: bamboo.RequirementEquals, MatchValue: "/opt/jdk-11"}).SatisfiedBy(capabilities))
+	assert.True(t, (&bamboo.Requirement{Key: "system.jdk.JDK 11", MatchType: bamboo.RequirementMatches, MatchValue: "/opt/jdk-1[0-9]"}).SatisfiedBy(capabilities))
+	assert.False(t, (&bamboo.Requirement{Key: "system.jdk.JDK 11", MatchType: bamboo.RequirementMatches, MatchValue: "jdk"}).SatisfiedBy(capabilities))
+	assert.False(t, (&bamboo.Requirement{Key: "system.jdk.JDK 8", MatchType: bamboo.RequirementExists}).SatisfiedBy(capabilities))
+}
+
+func TestMatchAgents(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(agentMatchingStub))
+	defer ts.Close()
+
+	client := bamboo.NewSimpleClient(nil, "", "")
+	client.SetURL(ts.URL)
+
+	report, err := client.Agents.MatchAgents([]string{"CORE-TEST"}, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, len(report.Jobs))
+
+	assert.Equal(t, "Build", report.Jobs[0].Job.StageName)
+	assert.Equal(t, 1, len(report.Jobs[0].Agents))
+	assert.Equal(t, "local", report.Jobs[0].Agents[0].Name)
+
+	unrunnable := report.Unrunnable()
+	assert.Equal(t, 2, len(unrunnable))
+
+	// Each requirement is met by some agent, just not all by the same one
+	assert.Equal(t, "CORE-TEST-JOB2", unrunnable[0].Job.Key)
+	assert.Empty(t, unrunnable[0].Unmet)
+
+	// Only the disabled agent has docker
+	assert.Equal(t, "CORE-TEST-DEPLOY", unrunnable[1].Job.Key)
+	assert.Equal(t, 1, len(unrunnable[1].Unmet))
+	assert.Equal(t, "docker", unrunnable[1].Unmet[0].Key)
+
+	assert.Equal(t, []*bamboo.PlanJob{report.Jobs[0].Job}, report.AgentJobs()[1])
+}
+
+func agentMatchingStub(w http.ResponseWriter, r *http.Request) {
+	path := strings.TrimPrefix(r.URL.Path, "/rest/api/latest/")
+
+	switch path {
+	case "agent":
+		fmt.Fprint(w, `[
+			{"id":1,"name":"local","type":"LOCAL","active":true,"enabled":true},
+			{"id":2,"name":"remote","type":"REMOTE","active":true,"enabled":true},
+			{"id":3,"name":"docker","type":"REMOTE","active":true,"enabled":false}
+		]`)
+	case "agent/1/status", "agent/2/status", "agent/3/status":
+		fmt.Fprint(w, `{"online":true}`)
+	case "agent/1/capability":
+		fmt.Fprint(w, `[{"key":"ant","value":"/usr/bin/ant"},{"key":"jdk","value":"11"}]`)
+	case "agent/2/capability":
+		fmt.Fprint(w, `[{"key":"mvn","value":"/usr/bin/mvn"},{"key":"jdk","value":"8"}]`)
+	case "agent/3/capability":
+		fmt.Fprint(w, `[{"key":"docker","value":"/usr/bin/docker"}]`)
+	case "plan/CORE-TEST.json":
+		fmt.Fprint(w, `{"stages":{"stage":[
+			{"name":"Build","plans":{"plan":[{"key":"CORE-TEST-JOB1","shortName":"Compile"},{"key":"CORE-TEST-JOB2","shortName":"Package"}]}},
+			{"name":"Deploy","plans":{"plan":[{"key":"CORE-TEST-DEPLOY","shortName":"Image"}]}}
+		]}}`)
+	case "config/job/CORE-TEST-JOB1/requirement":
+		fmt.Fprint(w, `[{"id":1,"key":"jdk","matchType":"EQUALS","matchValue":"11"}]`)
+	case "config/job/CORE-TEST-JOB2/requirement":
+		fmt.Fprint(w, `[{"id":2,"key":"mvn","matchType":"EXISTS"},{"id":3,"key":"jdk","matchType":"MATCHES","matchValue":"1[0-9]"}]`)
+	case "config/job/CORE-TEST-DEPLOY/requirement":
+		fmt.Fprint(w, `[{"id":4,"key":"docker","matchType":"EXISTS"}]`)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
@@ -0,0 +1,280 @@
+package bamboo
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// LocalAgent is the type of an agent running inside the Bamboo server
+const LocalAgent string = "LOCAL"
+
+// RemoteAgent is the type of an agent running on its own host
+const RemoteAgent string = "REMOTE"
+
+// ElasticAgent is the type of an agent running on an elastic cloud instance
+const ElasticAgent string = "ELASTIC"
+
+// AgentOnline is the status of an enabled agent that is connected and idle
+const AgentOnline string = "online"
+
+// AgentBusy is the status of an agent that is running a job
+const AgentBusy string = "busy"
+
+// AgentOffline is the status of an enabled agent that isn't connected to the server
+const AgentOffline string = "offline"
+
+// AgentDisabled is the status of an agent that won't pick up any new jobs
+const AgentDisabled string = "disabled"
+
+// AgentService handles communication with the build agents of a Bamboo server
+type AgentService service
+
+// Agent is a build agent. It is also the agent a deployment or build was executed on.
+// CurrentJob and Capabilities are only populated when requested through AgentListOptions.Details.
+type Agent struct {
+	ID           int           `json:"id"`
+	Name         string        `json:"name"`
+	Type         string        `json:"type"`
+	Active       bool          `json:"active"`
+	Enabled      bool          `json:"enabled"`
+	Busy         bool          `json:"busy"`
+	CurrentJob   *AgentJob     `json:"currentJob,omitempty"`
+	Capabilities []*Capability `json:"capabilities,omitempty"`
+}
+
+// Status returns one of AgentOnline, AgentBusy, AgentOffline or AgentDisabled.
+// A disabled agent may still be finishing the job it was running when it was disabled.
+func (a *Agent) Status() string {
+	switch {
+	case !a.Enabled:
+		return AgentDisabled
+	case !a.Active:
+		return AgentOffline
+	case a.Busy:
+		return AgentBusy
+	}
+	return AgentOnline
+}
+
+// AgentJob is the job an agent is currently running
+type AgentJob struct {
+	ResultKey string `json:"buildResultKey"`
+	PlanName  string `json:"planName"`
+	JobName   string `json:"jobName"`
+}
+
+// AgentStatus is the current state of a single agent
+type AgentStatus struct {
+	Online     bool      `json:"online"`
+	Enabled    bool      `json:"enabled"`
+	Busy       bool      `json:"busy"`
+	CurrentJob *AgentJob `json:"currentJob,omitempty"`
+}
+
+// Capability is something an agent offers to the jobs it runs, e.g. an executable or a JDK
+type Capability struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+// AgentAuthentication is a request from a remote agent to be allowed to connect to the server
+type AgentAuthentication struct {
+	UUID     string `json:"uuid"`
+	IP       string `json:"ip"`
+	Approved bool   `json:"approved"`
+}
+
+// AgentListOptions specifies the optional parameters for the ListAgents method
+// - Type:        Only list agents of this type, one of LocalAgent, RemoteAgent or ElasticAgent
+// - OnlineOnly:  Only list agents that are connected to the server
+// - Details:     Also fetch the current job and capabilities of every agent
+// - Concurrency: Maximum number of concurrent requests made when fetching details
+type AgentListOptions struct {
+	Type        string
+	OnlineOnly  bool
+	Details     bool
+	Concurrency int
+}
+
+// ListAgents returns the agents of the server
+func (a *AgentService) ListAgents(opts *AgentListOptions) ([]*Agent, *http.Response, error) {
+	if opts == nil {
+		opts = &AgentListOptions{}
+	}
+
+	u := "agent"
+	if opts.OnlineOnly {
+		u += "?online=true"
+	}
+
+	request, err := a.client.NewRequest(http.MethodGet, u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	data := []*Agent{}
+	response, err := a.client.Do(request, &data)
+	if err != nil {
+		return nil, response, err
+	}
+
+	if response.StatusCode == 401 {
+		return nil, response, &simpleError{"You must be an admin to access this information"}
+	} else if response.StatusCode != 200 {
+		return nil, response, &simpleError{fmt.Sprintf("Listing agents returned %s", response.Status)}
+	}
+
+	agents := []*Agent{}
+	for _, agent := range data {
+		if opts.Type == "" || agent.Type == opts.Type {
+			agents = append(agents, agent)
+		}
+	}
+
+	if !opts.Details {
+		return agents, response, nil
+	}
+
+	err = forEach(len(agents), opts.Concurrency, func(i int) error {
+		agent := agents[i]
+
+		status, _, err := a.AgentStatus(agent.ID)
+		if err != nil {
+			return err
+		}
+		agent.CurrentJob = status.CurrentJob
+
+		agent.Capabilities, _, err = a.AgentCapabilities(agent.ID)
+		return err
+	})
+	if err != nil {
+		return nil, response, err
+	}
+
+	return agents, response, nil
+}
+
+// AgentStatus returns the current state of the agent, including the job it is running if it is busy
+func (a *AgentService) AgentStatus(id int) (*AgentStatus, *http.Response, error) {
+	request, err := a.client.NewRequest(http.MethodGet, fmt.Sprintf("agent/%d/status", id), nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	status := &AgentStatus{}
+	response, err := a.client.Do(request, status)
+	if err != nil {
+		return nil, response, err
+	}
+
+	if response.StatusCode != 200 {
+		return nil, response, &simpleError{fmt.Sprintf("Retrieving status of agent %d returned %s", id, response.Status)}
+	}
+
+	return status, response, nil
+}
+
+// AgentCapabilities returns the capabilities of the agent
+func (a *AgentService) AgentCapabilities(id int) ([]*Capability, *http.Response, error) {
+	request, err := a.client.NewRequest(http.MethodGet, fmt.Sprintf("agent/%d/capability", id), nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	capabilities := []*Capability{}
+	response, err := a.client.Do(request, &capabilities)
+	if err != nil {
+		return nil, response, err
+	}
+
+	if response.StatusCode != 200 {
+		return nil, response, &simpleError{fmt.Sprintf("Retrieving capabilities of agent %d returned %s", id, response.Status)}
+	}
+
+	return capabilities, response, nil
+}
+
+// EnableAgent allows the agent to pick up new jobs
+func (a *AgentService) EnableAgent(id int) (*http.Response, error) {
+	return a.setAgentEnabled(id, "enable")
+}
+
+// DisableAgent stops the agent from picking up new jobs. A job it is already running is allowed to finish,
+// so wait for the agent to no longer be busy before taking its host down.
+func (a *AgentService) DisableAgent(id int) (*http.Response, error) {
+	return a.setAgentEnabled(id, "disable")
+}
+
+func (a *AgentService) setAgentEnabled(id int, action string) (*http.Response, error) {
+	request, err := a.client.NewRequest(http.MethodPut, fmt.Sprintf("agent/%d/%s", id, action), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := a.client.Do(request, nil)
+	if err != nil {
+		return response, err
+	}
+
+	switch response.StatusCode {
+	case 200, 204:
+		return response, nil
+	case 401:
+		return response, &simpleError{"You must be an admin to preform this action"}
+	case 404:
+		return response, &simpleError{fmt.Sprintf("Agent %d doesn't exist", id)}
+	}
+	return response, &simpleError{fmt.Sprintf("Server responded with unexpected return code %d", response.StatusCode)}
+}
+
+// AgentAuthentications returns the remote agent authentication requests. If pendingOnly is set,
+// requests that have already been approved are left out.
+func (a *AgentService) AgentAuthentications(pendingOnly bool) ([]*AgentAuthentication, *http.Response, error) {
+	u := "agent/authentication"
+	if pendingOnly {
+		u += "?pending=true"
+	}
+
+	request, err := a.client.NewRequest(http.MethodGet, u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	authentications := []*AgentAuthentication{}
+	response, err := a.client.Do(request, &authentications)
+	if err != nil {
+		return nil, response, err
+	}
+
+	if response.StatusCode == 401 {
+		return nil, response, &simpleError{"You must be an admin to access this information"}
+	} else if response.StatusCode != 200 {
+		return nil, response, &simpleError{fmt.Sprintf("Listing agent authentications returned %s", response.Status)}
+	}
+
+	return authentications, response, nil
+}
+
+// ApproveAgentAuthentication allows the remote agent with the given UUID to connect to the server
+func (a *AgentService) ApproveAgentAuthentication(uuid string) (*http.Response, error) {
+	request, err := a.client.NewRequest(http.MethodPut, fmt.Sprintf("agent/authentication/%s", url.PathEscape(uuid)), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := a.client.Do(request, nil)
+	if err != nil {
+		return response, err
+	}
+
+	switch response.StatusCode {
+	case 200, 204:
+		return response, nil
+	case 401:
+		return response, &simpleError{"You must be an admin to preform this action"}
+	case 404:
+		return response, &simpleError{fmt.Sprintf("No authentication request for agent %s", uuid)}
+	}
+	return response, &simpleError{fmt.Sprintf("Server responded with unexpected return code %d", response.StatusCode)}
+}
@@ -0,0 +1,96 @@
+package bamboo_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	bamboo "github.com/rcarmstrong/go-bamboo"
+)
+
+func TestListAgents(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(agentsStub))
+	defer ts.Close()
+
+	client := bamboo.NewSimpleClient(nil, "", "")
+	client.SetURL(ts.URL)
+
+	agents, _, err := client.Agents.ListAgents(nil)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, len(agents))
+	assert.Equal(t, bamboo.AgentOnline, agents[0].Status())
+	assert.Equal(t, bamboo.AgentBusy, agents[1].Status())
+	assert.Equal(t, bamboo.AgentDisabled, agents[2].Status())
+	assert.Nil(t, agents[1].CurrentJob)
+
+	agents, _, err = client.Agents.ListAgents(&bamboo.AgentListOptions{Type: bamboo.RemoteAgent, Details: true})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(agents))
+	assert.Equal(t, "CORE-TEST-JOB1-12", agents[0].CurrentJob.ResultKey)
+	assert.Equal(t, "ant", agents[0].Capabilities[0].Key)
+	assert.Nil(t, agents[1].CurrentJob)
+}
+
+func TestEnableDisableAgent(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(agentsStub))
+	defer ts.Close()
+
+	client := bamboo.NewSimpleClient(nil, "", "")
+	client.SetURL(ts.URL)
+
+	_, err := client.Agents.DisableAgent(2)
+	assert.NoError(t, err)
+	_, err = client.Agents.EnableAgent(2)
+	assert.NoError(t, err)
+	_, err = client.Agents.EnableAgent(99)
+	assert.Error(t, err)
+}
+
+func TestAgentAuthentication(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(agentsStub))
+	defer ts.Close()
+
+	client := bamboo.NewSimpleClient(nil, "", "")
+	client.SetURL(ts.URL)
+
+	pending, _, err := client.Agents.AgentAuthentications(true)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(pending))
+	assert.Equal(t, "10.0.0.5", pending[0].IP)
+
+	_, err = client.Agents.ApproveAgentAuthentication(pending[0].UUID)
+	assert.NoError(t, err)
+	_, err = client.Agents.ApproveAgentAuthentication("unknown")
+	assert.Error(t, err)
+}
+
+func agentsStub(w http.ResponseWriter, r *http.Request) {
+	path := strings.TrimPrefix(r.URL.Path, "/rest/api/latest/")
+
+	switch {
+	case path == "agent" && r.Method == http.MethodGet:
+		fmt.Fprint(w, `[
+			{"id":1,"name":"local","type":"LOCAL","active":true,"enabled":true,"busy":false},
+			{"id":2,"name":"remote-1","type":"REMOTE","active":true,"enabled":true,"busy":true},
+			{"id":3,"name":"remote-2","type":"REMOTE","active":true,"enabled":false,"busy":false}
+		]`)
+	case path == "agent/2/status":
+		fmt.Fprint(w, `{"online":true,"enabled":true,"busy":true,"currentJob":{"buildResultKey":"CORE-TEST-JOB1-12","planName":"Test","jobName":"Default Job"}}`)
+	case path == "agent/3/status":
+		fmt.Fprint(w, `{"online":true,"enabled":false,"busy":false}`)
+	case strings.HasSuffix(path, "/capability"):
+		fmt.Fprint(w, `[{"key":"ant","value":"/usr/bin/ant"}]`)
+	case (path == "agent/2/enable" || path == "agent/2/disable") && r.Method == http.MethodPut:
+		w.WriteHeader(http.StatusNoContent)
+	case path == "agent/authentication" && r.URL.Query().Get("pending") == "true":
+		fmt.Fprint(w, `[{"uuid":"0c7a0e2e-3f6f-4bdb-9f3e-8e2d1b6f6a11","ip":"10.0.0.5","approved":false}]`)
+	case path == "agent/authentication/0c7a0e2e-3f6f-4bdb-9f3e-8e2d1b6f6a11" && r.Method == http.MethodPut:
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
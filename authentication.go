@@ -1,6 +1,9 @@
 package bamboo
 
-import "encoding/base64"
+import (
+	"encoding/base64"
+	"net/http"
+)
 
 // Authorizer is the interface that wraps the Authorization method
 // Authorization returns the string to be used in the Authorization value of header
@@ -8,6 +11,58 @@ type Authorizer interface {
 	Authorization() string
 }
 
+// FallibleAuthorizer is an Authorizer whose credentials are loaded when a request is made and may fail to load.
+// NewRequest uses TryAuthorization when it is available so the failure is returned rather than a request
+// being sent without credentials.
+type FallibleAuthorizer interface {
+	Authorizer
+	TryAuthorization() (string, error)
+}
+
+func authorizationFor(creds Authorizer) (string, error) {
+	if fallible, ok := creds.(FallibleAuthorizer); ok {
+		return fallible.TryAuthorization()
+	}
+	return creds.Authorization(), nil
+}
+
+// RequestAuthorizer is an Authorizer that needs the request itself to authorize it, e.g. to sign it or to
+// attach session cookies. NewRequest calls AuthorizeRequest instead of setting the Authorization header
+// from Authorization, except for signatures which Do adds just before the request is sent.
+type RequestAuthorizer interface {
+	Authorizer
+	AuthorizeRequest(req *http.Request) error
+}
+
+// sessionAuthorizer is a RequestAuthorizer that keeps state from the server's responses, such as
+// SessionCredentials and CredentialProvider. observe reports whether the request should be authorized
+// and sent again.
+type sessionAuthorizer interface {
+	RequestAuthorizer
+	observe(req *http.Request, resp *http.Response) bool
+}
+
+// requestSigner is a RequestAuthorizer whose credentials are a signature over the request, such as
+// OAuthCredentials. Services often set the query after NewRequest, so Do signs the request instead.
+type requestSigner interface {
+	RequestAuthorizer
+	signsRequest()
+}
+
+// authorize adds the credentials of creds to req
+func authorize(req *http.Request, creds Authorizer) error {
+	if requestAuthorizer, ok := creds.(RequestAuthorizer); ok {
+		return requestAuthorizer.AuthorizeRequest(req)
+	}
+
+	authorization, err := authorizationFor(creds)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", authorization)
+	return nil
+}
+
 // SimpleCredentials are the username and password used to communicate with the API
 type SimpleCredentials struct {
 	Username string
@@ -24,6 +24,7 @@ import (
 	"net/http"
 	"net/url"
 	"strings"
+	"sync"
 	"time"
 )
 
@@ -37,21 +38,28 @@ type Client struct {
 	client     *http.Client // HTTP client used to communicate with the API
 	BaseURL    *url.URL
 	authorizer Authorizer // User credentials
+	authMu     sync.RWMutex
 
 	common service // Reuse a single struct instead of allocating one for each service on the heap.
 
 	// Services used for talking to different parts of the Bamboo API
-	Info        *InfoService
-	Plans       *PlanService
-	Deploys     *DeployService
-	Branches    *PlanBranchService
-	Projects    *ProjectService
-	Results     *ResultService
-	Comments    *CommentService
-	Labels      *LabelService
-	Clone       *CloneService
-	Server      *ServerService
-	Permissions *Permissions
+	Info         *InfoService
+	Plans        *PlanService
+	Deploys      *DeployService
+	Branches     *PlanBranchService
+	Projects     *ProjectService
+	Results      *ResultService
+	Comments     *CommentService
+	Labels       *LabelService
+	Clone        *CloneService
+	Server       *ServerService
+	Permissions  *Permissions
+	Agents       *AgentService
+	Elastic      *ElasticService
+	UserAdmin    *UserAdminService
+	Users        *UserService
+	AccessTokens *AccessTokenService
+	OAuth        *OAuthService
 }
 
 type service struct {
@@ -122,9 +130,29 @@ func NewClient(httpClient *http.Client, creds Authorizer) *Client {
 	c.Clone = (*CloneService)(&c.common)
 	c.Server = (*ServerService)(&c.common)
 	c.Permissions = (*Permissions)(&c.common)
+	c.Agents = (*AgentService)(&c.common)
+	c.Elastic = (*ElasticService)(&c.common)
+	c.UserAdmin = (*UserAdminService)(&c.common)
+	c.Users = (*UserService)(&c.common)
+	c.AccessTokens = (*AccessTokenService)(&c.common)
+	c.OAuth = (*OAuthService)(&c.common)
 	return c
 }
 
+// SetAuthorizer replaces the credentials used by every request made after it returns.
+// It is safe to call while other requests are in flight.
+func (c *Client) SetAuthorizer(creds Authorizer) {
+	c.authMu.Lock()
+	c.authorizer = creds
+	c.authMu.Unlock()
+}
+
+func (c *Client) currentAuthorizer() Authorizer {
+	c.authMu.RLock()
+	defer c.authMu.RUnlock()
+	return c.authorizer
+}
+
 // NewRequest creates an API request. A relative URL can be provided in urlStr,
 // in which case it is resolved relative to the BaseURL of the Client.
 // Relative URLs should always be specified without a preceding slash. If
@@ -155,8 +183,13 @@ func (c *Client) NewRequest(method, urlStr string, body interface{}) (*http.Requ
 		return nil, err
 	}
 
-	creds := c.authorizer
-	req.Header.Set("Authorization", creds.Authorization())
+	// Signed requests are authorized by Do once the caller has finished changing them
+	creds := c.currentAuthorizer()
+	if _, signs := creds.(requestSigner); !signs {
+		if err := authorize(req, creds); err != nil {
+			return nil, err
+		}
+	}
 	req.Header.Set("Accept", "application/json")
 
 	if body != nil {
@@ -171,13 +204,33 @@ func (c *Client) NewRequest(method, urlStr string, body interface{}) (*http.Requ
 // interface, the raw response body will be written to v, without attempting to
 // first decode it. If rate limit is exceeded and reset time is in the future,
 // Do returns *RateLimitError immediately without making a network API call.
+// Requests rejected because a session expired or credentials were rotated are authenticated and sent once more.
+// Clients whose credentials sign requests sign req here, unless it already carries an Authorization header.
 func (c *Client) Do(req *http.Request, v interface{}) (*http.Response, error) {
+	if signer, ok := c.currentAuthorizer().(requestSigner); ok && req.Header.Get("Authorization") == "" {
+		if err := signer.AuthorizeRequest(req); err != nil {
+			return nil, err
+		}
+	}
 
 	resp, err := c.client.Do(req)
 	if err != nil {
 		return nil, err
 	}
 
+	if session, ok := c.currentAuthorizer().(sessionAuthorizer); ok && session.observe(req, resp) {
+		if retry, rerr := retryRequest(req, session); rerr == nil {
+			io.Copy(ioutil.Discard, resp.Body)
+			resp.Body.Close()
+
+			resp, err = c.client.Do(retry)
+			if err != nil {
+				return nil, err
+			}
+			session.observe(retry, resp)
+		}
+	}
+
 	defer func() {
 		// Drain up to 512 bytes and close the body to let the Transport reuse the connection
 		io.CopyN(ioutil.Discard, resp.Body, 512)
@@ -197,3 +250,28 @@ func (c *Client) Do(req *http.Request, v interface{}) (*http.Response, error) {
 
 	return resp, err
 }
+
+// retryRequest copies req without its credentials and authorizes it again. Requests whose body
+// can't be read a second time aren't retried.
+func retryRequest(req *http.Request, session sessionAuthorizer) (*http.Request, error) {
+	if req.Body != nil && req.GetBody == nil {
+		return nil, &simpleError{"Request body can't be resent"}
+	}
+
+	retry := req.Clone(req.Context())
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		retry.Body = body
+	}
+	retry.Header.Del("Authorization")
+	retry.Header.Del("Cookie")
+	retry.Header.Del("X-Atlassian-Token")
+
+	if err := session.AuthorizeRequest(retry); err != nil {
+		return nil, err
+	}
+	return retry, nil
+}
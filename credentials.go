@@ -0,0 +1,373 @@
+package bamboo
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Default environment variables read by EnvCredentials
+const (
+	DefaultTokenEnv    = "BAMBOO_TOKEN"
+	DefaultUsernameEnv = "BAMBOO_USERNAME"
+	DefaultPasswordEnv = "BAMBOO_PASSWORD"
+)
+
+// defaultExecTimeout is how long an ExecCredentials helper may run when no timeout is set
+const defaultExecTimeout = 30 * time.Second
+
+// CredentialSource loads credentials from outside the program
+type CredentialSource interface {
+	Credentials() (Authorizer, error)
+}
+
+// CredentialProvider is an Authorizer that loads its credentials from a CredentialSource and caches them.
+// Credentials are reloaded once they are older than TTL, so long running programs pick up rotated
+// credentials without restarting. A zero TTL reloads them for every request, which suits cheap sources
+// such as environment variables and files. Credentials the server rejects are reloaded straight away and
+// the request is sent once more with the new ones. It is safe for concurrent use.
+type CredentialProvider struct {
+	Source CredentialSource
+	TTL    time.Duration
+
+	// loading is held while the source is read so only one load runs at a time, without
+	// blocking requests that can use the cached credentials
+	loading  sync.Mutex
+	mu       sync.RWMutex
+	cached   Authorizer
+	loadedAt time.Time
+}
+
+// NewCredentialProvider returns a provider caching the credentials of source for ttl
+func NewCredentialProvider(source CredentialSource, ttl time.Duration) *CredentialProvider {
+	return &CredentialProvider{Source: source, TTL: ttl}
+}
+
+// Authorization returns the Authorization header value of the current credentials, or an empty string
+// if they can't be loaded. NewRequest uses TryAuthorization instead so the error isn't lost.
+func (p *CredentialProvider) Authorization() string {
+	authorization, _ := p.TryAuthorization()
+	return authorization
+}
+
+// TryAuthorization returns the Authorization header value of the current credentials, loading them if
+// they haven't been loaded yet or have expired
+func (p *CredentialProvider) TryAuthorization() (string, error) {
+	creds, err := p.current()
+	if err != nil {
+		return "", err
+	}
+	return authorizationFor(creds)
+}
+
+// AuthorizeRequest adds the current credentials to req, loading them if they haven't been loaded yet
+// or have expired
+func (p *CredentialProvider) AuthorizeRequest(req *http.Request) error {
+	creds, err := p.current()
+	if err != nil {
+		return err
+	}
+	return authorize(req, creds)
+}
+
+// Refresh reloads the credentials straight away, e.g. after the server rejected them
+func (p *CredentialProvider) Refresh() error {
+	p.loading.Lock()
+	defer p.loading.Unlock()
+	_, err := p.load()
+	return err
+}
+
+// observe reloads the credentials when the server rejects the ones req was sent with. It reports
+// whether there are different credentials to send the request with again.
+func (p *CredentialProvider) observe(req *http.Request, resp *http.Response) bool {
+	if resp.StatusCode != http.StatusUnauthorized {
+		return false
+	}
+
+	p.loading.Lock()
+	defer p.loading.Unlock()
+
+	sent := req.Header.Get("Authorization")
+	// Another request may already have replaced the rejected credentials
+	if creds := p.cachedCredentials(); creds != nil {
+		if authorization, err := authorizationFor(creds); err == nil && authorization != sent {
+			return true
+		}
+	}
+
+	creds, err := p.load()
+	if err != nil {
+		return false
+	}
+	authorization, err := authorizationFor(creds)
+	return err == nil && authorization != sent
+}
+
+// current returns the cached credentials, loading them first if they haven't been loaded yet or have expired
+func (p *CredentialProvider) current() (Authorizer, error) {
+	if creds := p.fresh(); creds != nil {
+		return creds, nil
+	}
+
+	p.loading.Lock()
+	defer p.loading.Unlock()
+
+	// They may have been loaded while waiting for another load to finish
+	if creds := p.fresh(); creds != nil {
+		return creds, nil
+	}
+	return p.load()
+}
+
+// fresh returns the cached credentials, or nil if they haven't been loaded yet or have expired
+func (p *CredentialProvider) fresh() Authorizer {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	if p.cached == nil || p.TTL <= 0 || time.Since(p.loadedAt) >= p.TTL {
+		return nil
+	}
+	return p.cached
+}
+
+func (p *CredentialProvider) cachedCredentials() Authorizer {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.cached
+}
+
+// load reads the credentials from the source and caches them. The caller must hold p.loading.
+func (p *CredentialProvider) load() (Authorizer, error) {
+	creds, err := p.Source.Credentials()
+	if err != nil {
+		return nil, err
+	}
+
+	p.mu.Lock()
+	p.cached = creds
+	p.loadedAt = time.Now()
+	p.mu.Unlock()
+	return creds, nil
+}
+
+// EnvCredentials reads credentials from environment variables. A token takes precedence over a
+// username and password. Variables left blank default to DefaultTokenEnv, DefaultUsernameEnv
+// and DefaultPasswordEnv.
+type EnvCredentials struct {
+	TokenVar    string
+	UsernameVar string
+	PasswordVar string
+}
+
+// Credentials implements CredentialSource
+func (e *EnvCredentials) Credentials() (Authorizer, error) {
+	tokenVar, usernameVar, passwordVar := e.TokenVar, e.UsernameVar, e.PasswordVar
+	if tokenVar == "" {
+		tokenVar = DefaultTokenEnv
+	}
+	if usernameVar == "" {
+		usernameVar = DefaultUsernameEnv
+	}
+	if passwordVar == "" {
+		passwordVar = DefaultPasswordEnv
+	}
+
+	if token := os.Getenv(tokenVar); token != "" {
+		return &TokenCredentials{Token: token}, nil
+	}
+	if username := os.Getenv(usernameVar); username != "" {
+		return &SimpleCredentials{Username: username, Password: os.Getenv(passwordVar)}, nil
+	}
+	return nil, &simpleError{fmt.Sprintf("Neither %s nor %s is set", tokenVar, usernameVar)}
+}
+
+// NetrcCredentials reads the login and password of a machine from a .netrc file.
+// Path defaults to $NETRC, then ~/.netrc. The default entry is used if no machine matches Host.
+type NetrcCredentials struct {
+	Path string
+	Host string
+}
+
+// Credentials implements CredentialSource
+func (n *NetrcCredentials) Credentials() (Authorizer, error) {
+	path := n.Path
+	if path == "" {
+		path = os.Getenv("NETRC")
+	}
+	if path == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return nil, err
+		}
+		path = filepath.Join(home, ".netrc")
+	}
+
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	login, password, ok := parseNetrc(data, n.Host)
+	if !ok {
+		return nil, &simpleError{fmt.Sprintf("No entry for %s in %s", n.Host, path)}
+	}
+	return &SimpleCredentials{Username: login, Password: password}, nil
+}
+
+// parseNetrc returns the login and password of the entry for host, falling back to the default entry
+func parseNetrc(data []byte, host string) (login, password string, ok bool) {
+	type entry struct{ login, password string }
+	var (
+		machines = map[string]*entry{}
+		fallback *entry
+		current  *entry
+	)
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Split(bufio.ScanWords)
+	for scanner.Scan() {
+		switch scanner.Text() {
+		case "machine":
+			if !scanner.Scan() {
+				break
+			}
+			current = &entry{}
+			if _, exists := machines[scanner.Text()]; !exists {
+				machines[scanner.Text()] = current
+			}
+		case "default":
+			current = &entry{}
+			fallback = current
+		case "login":
+			if scanner.Scan() && current != nil {
+				current.login = scanner.Text()
+			}
+		case "password":
+			if scanner.Scan() && current != nil {
+				current.password = scanner.Text()
+			}
+		case "macdef":
+			// Macros run until the next blank line which word splitting can't see, so stop here
+			current = nil
+		}
+	}
+
+	if e, found := machines[host]; found {
+		return e.login, e.password, true
+	}
+	if fallback != nil {
+		return fallback.login, fallback.password, true
+	}
+	return "", "", false
+}
+
+// FileCredentials reads credentials from a file, re-reading it only when it changes.
+// See parseCredentials for the accepted formats.
+type FileCredentials struct {
+	Path string
+
+	mu      sync.Mutex
+	modTime time.Time
+	size    int64
+	cached  Authorizer
+}
+
+// Credentials implements CredentialSource
+func (f *FileCredentials) Credentials() (Authorizer, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	info, err := os.Stat(f.Path)
+	if err != nil {
+		return nil, err
+	}
+	if f.cached != nil && info.ModTime().Equal(f.modTime) && info.Size() == f.size {
+		return f.cached, nil
+	}
+
+	data, err := ioutil.ReadFile(f.Path)
+	if err != nil {
+		return nil, err
+	}
+	creds, err := parseCredentials(data)
+	if err != nil {
+		return nil, &simpleError{fmt.Sprintf("Reading credentials from %s: %s", f.Path, err)}
+	}
+
+	f.cached, f.modTime, f.size = creds, info.ModTime(), info.Size()
+	return creds, nil
+}
+
+// ExecCredentials runs a helper command and reads credentials from its standard output.
+// See parseCredentials for the accepted formats. Timeout defaults to 30 seconds.
+type ExecCredentials struct {
+	Command string
+	Args    []string
+	Timeout time.Duration
+}
+
+// Credentials implements CredentialSource
+func (e *ExecCredentials) Credentials() (Authorizer, error) {
+	timeout := e.Timeout
+	if timeout <= 0 {
+		timeout = defaultExecTimeout
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	stderr := &bytes.Buffer{}
+	cmd := exec.CommandContext(ctx, e.Command, e.Args...)
+	cmd.Stderr = stderr
+
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, &simpleError{fmt.Sprintf("Credential helper %s failed: %s %s", e.Command, err, strings.TrimSpace(stderr.String()))}
+	}
+
+	creds, err := parseCredentials(out)
+	if err != nil {
+		return nil, &simpleError{fmt.Sprintf("Credential helper %s: %s", e.Command, err)}
+	}
+	return creds, nil
+}
+
+// parseCredentials reads either a JSON object with "token" or "username" and "password" keys,
+// or a bare token on its own
+func parseCredentials(data []byte) (Authorizer, error) {
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 {
+		return nil, &simpleError{"No credentials found"}
+	}
+
+	if data[0] != '{' {
+		return &TokenCredentials{Token: string(data)}, nil
+	}
+
+	fields := struct {
+		Token    string `json:"token"`
+		Username string `json:"username"`
+		Password string `json:"password"`
+	}{}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+
+	switch {
+	case fields.Token != "":
+		return &TokenCredentials{Token: fields.Token}, nil
+	case fields.Username != "":
+		return &SimpleCredentials{Username: fields.Username, Password: fields.Password}, nil
+	}
+	return nil, &simpleError{"No token or username found"}
+}
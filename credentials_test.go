@@ -0,0 +1,211 @@
+package bamboo_test
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	bamboo "github.com/rcarmstrong/go-bamboo"
+)
+
+func TestEnvCredentials(t *testing.T) {
+	os.Setenv("TEST_BAMBOO_USER", "alice")
+	os.Setenv("TEST_BAMBOO_PASS", "secret")
+	defer os.Unsetenv("TEST_BAMBOO_USER")
+	defer os.Unsetenv("TEST_BAMBOO_PASS")
+
+	source := &bamboo.EnvCredentials{TokenVar: "TEST_BAMBOO_TOKEN", UsernameVar: "TEST_BAMBOO_USER", PasswordVar: "TEST_BAMBOO_PASS"}
+	creds, err := source.Credentials()
+	assert.NoError(t, err)
+	assert.Equal(t, (&bamboo.SimpleCredentials{Username: "alice", Password: "secret"}).Authorization(), creds.Authorization())
+
+	os.Setenv("TEST_BAMBOO_TOKEN", "abc")
+	defer os.Unsetenv("TEST_BAMBOO_TOKEN")
+	creds, err = source.Credentials()
+	assert.NoError(t, err)
+	assert.Equal(t, "Bearer abc", creds.Authorization())
+
+	_, err = (&bamboo.EnvCredentials{TokenVar: "TEST_BAMBOO_MISSING", UsernameVar: "TEST_BAMBOO_MISSING"}).Credentials()
+	assert.Error(t, err)
+}
+
+func TestNetrcCredentials(t *testing.T) {
+	dir, err := ioutil.TempDir("", "netrc")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, ".netrc")
+	assert.NoError(t, ioutil.WriteFile(path, []byte("machine github.com login octo password cat\n"+
+		"machine bamboo.example.com\n  login alice\n  password secret\n"+
+		"default login anon password none\n"), 0600))
+
+	creds, err := (&bamboo.NetrcCredentials{Path: path, Host: "bamboo.example.com"}).Credentials()
+	assert.NoError(t, err)
+	assert.Equal(t, (&bamboo.SimpleCredentials{Username: "alice", Password: "secret"}).Authorization(), creds.Authorization())
+
+	creds, err = (&bamboo.NetrcCredentials{Path: path, Host: "other.example.com"}).Credentials()
+	assert.NoError(t, err)
+	assert.Equal(t, (&bamboo.SimpleCredentials{Username: "anon", Password: "none"}).Authorization(), creds.Authorization())
+}
+
+func TestFileCredentialsRefresh(t *testing.T) {
+	dir, err := ioutil.TempDir("", "credentials")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "token")
+	assert.NoError(t, ioutil.WriteFile(path, []byte("first\n"), 0600))
+
+	var seen []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		seen = append(seen, r.Header.Get("Authorization"))
+		w.Write([]byte(`{"name":"alice"}`))
+	}))
+	defer ts.Close()
+
+	client := bamboo.NewClient(nil, bamboo.NewCredentialProvider(&bamboo.FileCredentials{Path: path}, 0))
+	client.SetURL(ts.URL)
+
+	user, _, err := client.Users.CurrentUser()
+	assert.NoError(t, err)
+	assert.Equal(t, "alice", user.Name)
+
+	assert.NoError(t, ioutil.WriteFile(path, []byte(`{"username":"alice","password":"rotated"}`), 0600))
+	later := time.Now().Add(time.Minute)
+	assert.NoError(t, os.Chtimes(path, later, later))
+
+	request, err := client.NewRequest(http.MethodGet, "currentUser", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "Bearer first", seen[0])
+	assert.Equal(t, (&bamboo.SimpleCredentials{Username: "alice", Password: "rotated"}).Authorization(), request.Header.Get("Authorization"))
+
+	// A credential failure is returned by NewRequest instead of sending an empty header
+	os.Remove(path)
+	_, err = client.NewRequest(http.MethodGet, "currentUser", nil)
+	assert.Error(t, err)
+}
+
+type countingSource struct {
+	loads int
+}
+
+func (c *countingSource) Credentials() (bamboo.Authorizer, error) {
+	c.loads++
+	return &bamboo.TokenCredentials{Token: "token"}, nil
+}
+
+func TestCredentialProviderCaching(t *testing.T) {
+	source := &countingSource{}
+	provider := bamboo.NewCredentialProvider(source, time.Hour)
+
+	for i := 0; i < 3; i++ {
+		authorization, err := provider.TryAuthorization()
+		assert.NoError(t, err)
+		assert.Equal(t, "Bearer token", authorization)
+	}
+	assert.Equal(t, 1, source.loads)
+
+	assert.NoError(t, provider.Refresh())
+	assert.Equal(t, 2, source.loads)
+}
+
+type rotatingSource struct {
+	tokens []string
+	loads  int
+}
+
+func (r *rotatingSource) Credentials() (bamboo.Authorizer, error) {
+	token := r.tokens[r.loads]
+	if r.loads < len(r.tokens)-1 {
+		r.loads++
+	}
+	return &bamboo.TokenCredentials{Token: token}, nil
+}
+
+func TestCredentialProviderRejected(t *testing.T) {
+	var seen []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		seen = append(seen, r.Header.Get("Authorization"))
+		if r.Header.Get("Authorization") != "Bearer new" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Write([]byte(`{"name":"alice"}`))
+	}))
+	defer ts.Close()
+
+	source := &rotatingSource{tokens: []string{"old", "new"}}
+	client := bamboo.NewClient(nil, bamboo.NewCredentialProvider(source, time.Hour))
+	client.SetURL(ts.URL)
+
+	// The rejected token is reloaded and the request sent once more
+	request, err := client.NewRequest(http.MethodGet, "currentUser", nil)
+	assert.NoError(t, err)
+	response, err := client.Do(request, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+	assert.Equal(t, []string{"Bearer old", "Bearer new"}, seen)
+
+	// Reloading the same credentials doesn't send the request again
+	seen = nil
+	client.SetAuthorizer(bamboo.NewCredentialProvider(&rotatingSource{tokens: []string{"wrong"}}, time.Hour))
+	request, err = client.NewRequest(http.MethodGet, "currentUser", nil)
+	assert.NoError(t, err)
+	response, err = client.Do(request, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusUnauthorized, response.StatusCode)
+	assert.Equal(t, []string{"Bearer wrong"}, seen)
+}
+
+type blockingSource struct {
+	release chan struct{}
+	loads   int
+}
+
+func (b *blockingSource) Credentials() (bamboo.Authorizer, error) {
+	b.loads++
+	if b.loads > 1 {
+		<-b.release
+	}
+	return &bamboo.TokenCredentials{Token: "token"}, nil
+}
+
+func TestCredentialProviderSlowRefresh(t *testing.T) {
+	source := &blockingSource{release: make(chan struct{})}
+	provider := bamboo.NewCredentialProvider(source, time.Hour)
+
+	_, err := provider.TryAuthorization()
+	assert.NoError(t, err)
+
+	refreshed := make(chan error)
+	go func() { refreshed <- provider.Refresh() }()
+
+	// The cached credentials are still served while the source is being read
+	authorization, err := provider.TryAuthorization()
+	assert.NoError(t, err)
+	assert.Equal(t, "Bearer token", authorization)
+
+	close(source.release)
+	assert.NoError(t, <-refreshed)
+}
+
+func TestExecCredentials(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("requires a POSIX shell")
+	}
+
+	creds, err := (&bamboo.ExecCredentials{Command: "sh", Args: []string{"-c", `echo '{"token":"from-helper"}'`}}).Credentials()
+	assert.NoError(t, err)
+	assert.Equal(t, "Bearer from-helper", creds.Authorization())
+
+	_, err = (&bamboo.ExecCredentials{Command: "sh", Args: []string{"-c", "echo broken >&2; exit 1"}}).Credentials()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "broken")
+}
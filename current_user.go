@@ -0,0 +1,127 @@
+package bamboo
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// favouritesPageSize is the number of favourite plans requested per page
+const favouritesPageSize = 100
+
+// UserService handles communication with the methods acting on the authenticated user
+type UserService service
+
+// CurrentUser is the user the client is authenticated as.
+// GlobalPermissions holds the user's effective global permissions, including those granted through
+// their groups and the LOGGED_IN and ANONYMOUS roles. It is nil if they couldn't be read, e.g. because
+// the user isn't an admin.
+type CurrentUser struct {
+	Name              string       `json:"name"`
+	FullName          string       `json:"fullName"`
+	Email             string       `json:"email"`
+	GlobalPermissions []Permission `json:"-"`
+}
+
+// CurrentUser returns the authenticated user along with their global permissions.
+// It can be used to check that the client's credentials are accepted by the server.
+func (u *UserService) CurrentUser() (*CurrentUser, *http.Response, error) {
+	request, err := u.client.NewRequest(http.MethodGet, "currentUser", nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	user := &CurrentUser{}
+	response, err := u.client.Do(request, user)
+	if err != nil {
+		return nil, response, err
+	}
+
+	if response.StatusCode == 401 {
+		return nil, response, &simpleError{"The server rejected the client's credentials"}
+	} else if response.StatusCode != 200 {
+		return nil, response, &simpleError{fmt.Sprintf("Retrieving the current user returned %s", response.Status)}
+	}
+
+	// An anonymous request is answered with an empty user rather than a 401
+	if user.Name == "" {
+		return nil, response, &simpleError{"The client is not authenticated"}
+	}
+
+	// Permissions are extra information, failing to read them doesn't make the credentials invalid
+	effective, err := u.client.Permissions.EffectivePermissions(user.Name, PermissionsOpts{Resource: GlobalResource})
+	if err == nil {
+		user.GlobalPermissions = effective.List()
+	}
+	return user, response, nil
+}
+
+// Favourites returns the plans the authenticated user has marked as favourite
+func (u *UserService) Favourites() ([]*Plan, error) {
+	plans := []*Plan{}
+	page := &Pagination{Limit: favouritesPageSize}
+	for {
+		values := url.Values{}
+		values.Set("favourite", "true")
+		page.setQuery(values)
+
+		request, err := u.client.NewRequest(http.MethodGet, "plan.json?"+values.Encode(), nil)
+		if err != nil {
+			return nil, err
+		}
+
+		planResp := PlanResponse{}
+		response, err := u.client.Do(request, &planResp)
+		if err != nil {
+			return nil, err
+		}
+
+		if response.StatusCode != 200 {
+			return nil, &simpleError{fmt.Sprintf("Listing favourite plans returned %s", response.Status)}
+		}
+		if planResp.Plans == nil {
+			return plans, nil
+		}
+
+		plans = append(plans, planResp.Plans.PlanList...)
+		if !page.advance(len(planResp.Plans.PlanList), planResp.Plans.CollectionMetadata) {
+			return plans, nil
+		}
+	}
+}
+
+// AddFavourite marks the plan as a favourite of the authenticated user
+func (u *UserService) AddFavourite(planKey string) (*http.Response, error) {
+	return u.changeFavourite(http.MethodPost, planKey)
+}
+
+// RemoveFavourite removes the plan from the authenticated user's favourites
+func (u *UserService) RemoveFavourite(planKey string) (*http.Response, error) {
+	return u.changeFavourite(http.MethodDelete, planKey)
+}
+
+func (u *UserService) changeFavourite(method, planKey string) (*http.Response, error) {
+	if planKey == "" {
+		return nil, &simpleError{"A plan key is required"}
+	}
+
+	request, err := u.client.NewRequest(method, fmt.Sprintf("plan/%s/favourite", planKey), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := u.client.Do(request, nil)
+	if err != nil {
+		return response, err
+	}
+
+	switch response.StatusCode {
+	case 200, 204:
+		return response, nil
+	case 401:
+		return response, &simpleError{"You must be logged in to manage favourites"}
+	case 404:
+		return response, &simpleError{fmt.Sprintf("Plan %s doesn't exist", planKey)}
+	}
+	return response, &simpleError{fmt.Sprintf("Server responded with unexpected return code %d", response.StatusCode)}
+}
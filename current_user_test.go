@@ -0,0 +1,132 @@
+package bamboo_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	bamboo "github.com/rcarmstrong/go-bamboo"
+)
+
+func TestCurrentUser(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(currentUserStub))
+	defer ts.Close()
+
+	client := bamboo.NewSimpleClient(nil, "alice", "secret")
+	client.SetURL(ts.URL)
+
+	user, _, err := client.Users.CurrentUser()
+	assert.NoError(t, err)
+	assert.Equal(t, "alice", user.Name)
+	// CREATE and CREATEREPOSITORY come from alice's group, READ from her own grant and LOGGED_IN
+	assert.Equal(t, []bamboo.Permission{bamboo.CreatePermission, bamboo.CreateRepositoryPermission, bamboo.ReadPermission}, user.GlobalPermissions)
+
+	// bob can't read global permissions but is still a valid user
+	client = bamboo.NewSimpleClient(nil, "bob", "secret")
+	client.SetURL(ts.URL)
+
+	user, _, err = client.Users.CurrentUser()
+	assert.NoError(t, err)
+	assert.Equal(t, "bob", user.Name)
+	assert.Nil(t, user.GlobalPermissions)
+
+	// A failure reading carol's permissions doesn't fail the credential check
+	client = bamboo.NewSimpleClient(nil, "carol", "secret")
+	client.SetURL(ts.URL)
+
+	user, _, err = client.Users.CurrentUser()
+	assert.NoError(t, err)
+	assert.Equal(t, "carol", user.Name)
+	assert.Nil(t, user.GlobalPermissions)
+
+	client = bamboo.NewSimpleClient(nil, "mallory", "wrong")
+	client.SetURL(ts.URL)
+
+	_, _, err = client.Users.CurrentUser()
+	assert.Error(t, err)
+}
+
+func TestFavourites(t *testing.T) {
+	var changes []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path := strings.TrimPrefix(r.URL.Path, "/rest/api/latest/")
+		switch {
+		case path == "plan.json" && r.URL.Query().Get("favourite") == "true":
+			// Pages are served two at a time whatever the client asks for, as Bamboo caps the page size
+			if r.URL.Query().Get("max-result") == "" {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			switch r.URL.Query().Get("start-index") {
+			case "0":
+				fmt.Fprint(w, `{"plans":{"size":3,"start-index":0,"max-result":2,"plan":[{"key":"CORE-TEST","name":"Test"},{"key":"WEB-SITE","name":"Site"}]}}`)
+			case "2":
+				fmt.Fprint(w, `{"plans":{"size":3,"start-index":2,"max-result":2,"plan":[{"key":"WEB-API","name":"API"}]}}`)
+			default:
+				fmt.Fprint(w, `{"plans":{"size":3,"plan":[]}}`)
+			}
+		case path == "plan/CORE-TEST/favourite":
+			changes = append(changes, r.Method)
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	client := bamboo.NewSimpleClient(nil, "", "")
+	client.SetURL(ts.URL)
+
+	plans, err := client.Users.Favourites()
+	assert.NoError(t, err)
+	assert.Equal(t, 3, len(plans))
+	assert.Equal(t, "WEB-API", plans[2].Key)
+
+	_, err = client.Users.AddFavourite("CORE-TEST")
+	assert.NoError(t, err)
+	_, err = client.Users.RemoveFavourite("CORE-TEST")
+	assert.NoError(t, err)
+	_, err = client.Users.AddFavourite("NOPE-NOPE")
+	assert.Error(t, err)
+	assert.Equal(t, []string{http.MethodPost, http.MethodDelete}, changes)
+}
+
+func currentUserStub(w http.ResponseWriter, r *http.Request) {
+	username, _, _ := r.BasicAuth()
+	path := strings.TrimPrefix(r.URL.Path, "/rest/api/latest/")
+
+	if username == "mallory" {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	switch path {
+	case "currentUser":
+		fmt.Fprintf(w, `{"name":"%s","email":"%s@example.com"}`, username, username)
+	case "permissions/global/users", "permissions/global/groups", "permissions/global/roles", "admin/users/alice/groups":
+		if username == "carol" {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		if username != "alice" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch path {
+		case "permissions/global/users":
+			fmt.Fprint(w, `{"results":[{"name":"alice","permissions":["READ"]}]}`)
+		case "permissions/global/groups":
+			fmt.Fprint(w, `{"results":[{"name":"builders","permissions":["CREATE","CREATEREPOSITORY"]},{"name":"admins","permissions":["ADMINISTRATION"]}]}`)
+		case "permissions/global/roles":
+			fmt.Fprint(w, `{"results":[{"name":"LOGGED_IN","permissions":["READ"]}]}`)
+		default:
+			fmt.Fprint(w, `{"results":[{"name":"builders"}],"isLastPage":true}`)
+		}
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
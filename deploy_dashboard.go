@@ -0,0 +1,71 @@
+package bamboo
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// DeploymentProjectStatus is a deployment project and the current state of each of its environments
+type DeploymentProjectStatus struct {
+	DeploymentProject   *Deploy              `json:"deploymentProject"`
+	EnvironmentStatuses []*EnvironmentStatus `json:"environmentStatuses"`
+}
+
+// EnvironmentStatus is an environment and its most recent deployment.
+// DeploymentResult is nil if nothing has been deployed to the environment.
+type EnvironmentStatus struct {
+	Environment      *DeployEnvironment `json:"environment"`
+	DeploymentResult *DeploymentResult  `json:"deploymentResult,omitempty"`
+}
+
+// DashboardOptions specifies the optional parameters for the Dashboard method
+// - DeploymentProjectIDs: Only return these deployment projects. All projects are returned if empty
+// - Concurrency:          Maximum number of concurrent requests when DeploymentProjectIDs is set
+type DashboardOptions struct {
+	DeploymentProjectIDs []int
+	Concurrency          int
+}
+
+// Dashboard returns what is deployed to every environment of every deployment project,
+// the data behind Bamboo's deployment dashboard.
+func (d *DeployService) Dashboard(opts *DashboardOptions) ([]*DeploymentProjectStatus, error) {
+	if opts == nil || len(opts.DeploymentProjectIDs) == 0 {
+		return d.dashboard("deploy/dashboard/")
+	}
+
+	ids := opts.DeploymentProjectIDs
+	results := make([][]*DeploymentProjectStatus, len(ids))
+	err := forEach(len(ids), opts.Concurrency, func(i int) error {
+		statuses, err := d.dashboard(fmt.Sprintf("deploy/dashboard/%d", ids[i]))
+		results[i] = statuses
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := []*DeploymentProjectStatus{}
+	for _, r := range results {
+		statuses = append(statuses, r...)
+	}
+	return statuses, nil
+}
+
+func (d *DeployService) dashboard(u string) ([]*DeploymentProjectStatus, error) {
+	request, err := d.client.NewRequest(http.MethodGet, u, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := []*DeploymentProjectStatus{}
+	response, err := d.client.Do(request, &statuses)
+	if err != nil {
+		return nil, err
+	}
+
+	if response.StatusCode != http.StatusOK {
+		return nil, newRespErr(response, "Error getting deployment dashboard")
+	}
+
+	return statuses, nil
+}
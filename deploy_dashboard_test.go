@@ -0,0 +1,59 @@
+package bamboo_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	bamboo "github.com/rcarmstrong/go-bamboo"
+)
+
+func TestDashboard(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(dashboardStub))
+	defer ts.Close()
+
+	client := bamboo.NewSimpleClient(nil, "", "")
+	client.SetURL(ts.URL)
+
+	statuses, err := client.Deploys.Dashboard(nil)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(statuses))
+
+	statuses, err = client.Deploys.Dashboard(&bamboo.DashboardOptions{DeploymentProjectIDs: []int{1, 2}, Concurrency: 2})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(statuses))
+	assert.Equal(t, 1, statuses[0].DeploymentProject.ID)
+	assert.Equal(t, 2, statuses[1].DeploymentProject.ID)
+
+	status := statuses[0].EnvironmentStatuses[0]
+	assert.Equal(t, "prod", status.Environment.Name)
+	assert.Equal(t, "release-3", status.DeploymentResult.DeploymentVersionName)
+	assert.Equal(t, int64(1546300800000), status.DeploymentResult.StartedDate.UnixNano()/1e6)
+	assert.Nil(t, statuses[1].EnvironmentStatuses[0].DeploymentResult)
+
+	_, err = client.Deploys.Dashboard(&bamboo.DashboardOptions{DeploymentProjectIDs: []int{1, 5}})
+	assert.Error(t, err)
+}
+
+const (
+	dashboardProjectOne = `{"deploymentProject":{"id":1,"name":"Core"},"environmentStatuses":[
+		{"environment":{"id":10,"name":"prod"},"deploymentResult":{"id":4,"deploymentVersionName":"release-3",
+		 "deploymentState":"SUCCESS","lifeCycleState":"FINISHED","startedDate":1546300800000}}]}`
+	dashboardProjectTwo = `{"deploymentProject":{"id":2,"name":"Web"},"environmentStatuses":[{"environment":{"id":20,"name":"prod"}}]}`
+)
+
+func dashboardStub(w http.ResponseWriter, r *http.Request) {
+	switch r.URL.Path {
+	case "/rest/api/latest/deploy/dashboard/":
+		fmt.Fprintf(w, "[%s,%s]", dashboardProjectOne, dashboardProjectTwo)
+	case "/rest/api/latest/deploy/dashboard/1":
+		fmt.Fprintf(w, "[%s]", dashboardProjectOne)
+	case "/rest/api/latest/deploy/dashboard/2":
+		fmt.Fprintf(w, "[%s]", dashboardProjectTwo)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
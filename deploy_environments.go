@@ -0,0 +1,297 @@
+package bamboo
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// EnvironmentTrigger is a trigger that starts deployments to an environment
+type EnvironmentTrigger struct {
+	ID            int               `json:"id,omitempty"`
+	Name          string            `json:"name"`
+	Description   string            `json:"description,omitempty"`
+	PluginKey     string            `json:"pluginKey"`
+	Enabled       bool              `json:"enabled"`
+	Configuration map[string]string `json:"configuration,omitempty"`
+}
+
+// DeployEnvironmentConfig is the variables, requirements and triggers configured for an environment
+type DeployEnvironmentConfig struct {
+	EnvironmentID int                   `json:"environmentId"`
+	Variables     []*Variable           `json:"variables"`
+	Requirements  []*Requirement        `json:"requirements"`
+	Triggers      []*EnvironmentTrigger `json:"triggers"`
+}
+
+// EnvironmentConfig returns the variables, requirements and triggers of the given environment
+func (d *DeployService) EnvironmentConfig(environmentID int) (*DeployEnvironmentConfig, error) {
+	variables, err := d.EnvironmentVariables(environmentID)
+	if err != nil {
+		return nil, err
+	}
+
+	requirements, err := d.EnvironmentRequirements(environmentID)
+	if err != nil {
+		return nil, err
+	}
+
+	triggers, err := d.EnvironmentTriggers(environmentID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &DeployEnvironmentConfig{
+		EnvironmentID: environmentID,
+		Variables:     variables,
+		Requirements:  requirements,
+		Triggers:      triggers,
+	}, nil
+}
+
+// -- Variables --
+
+// EnvironmentVariables returns the variables defined on the given environment
+func (d *DeployService) EnvironmentVariables(environmentID int) ([]*Variable, error) {
+	request, err := d.client.NewRequest(http.MethodGet, fmt.Sprintf("deploy/environment/%d/variables", environmentID), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	variables := []*Variable{}
+	response, err := d.client.Do(request, &variables)
+	if err != nil {
+		return nil, err
+	}
+
+	if response.StatusCode != http.StatusOK {
+		return nil, newRespErr(response, "Error getting environment variables")
+	}
+
+	return variables, nil
+}
+
+// CreateEnvironmentVariable adds a new variable to the given environment
+func (d *DeployService) CreateEnvironmentVariable(environmentID int, variable *Variable) error {
+	request, err := d.client.NewRequest(http.MethodPost, fmt.Sprintf("deploy/environment/%d/variables", environmentID), variable)
+	if err != nil {
+		return err
+	}
+
+	response, err := d.client.Do(request, nil)
+	if err != nil {
+		return err
+	}
+
+	if response.StatusCode != http.StatusOK && response.StatusCode != http.StatusCreated {
+		return newRespErr(response, "Error creating environment variable")
+	}
+
+	return nil
+}
+
+// UpdateEnvironmentVariable changes the value of an existing variable on the given environment
+func (d *DeployService) UpdateEnvironmentVariable(environmentID int, variable *Variable) error {
+	u := fmt.Sprintf("deploy/environment/%d/variables/%s", environmentID, url.PathEscape(variable.Name))
+	request, err := d.client.NewRequest(http.MethodPut, u, variable)
+	if err != nil {
+		return err
+	}
+
+	response, err := d.client.Do(request, nil)
+	if err != nil {
+		return err
+	}
+
+	if response.StatusCode != http.StatusOK && response.StatusCode != http.StatusNoContent {
+		return newRespErr(response, "Error updating environment variable")
+	}
+
+	return nil
+}
+
+// DeleteEnvironmentVariable removes the named variable from the given environment
+func (d *DeployService) DeleteEnvironmentVariable(environmentID int, name string) error {
+	u := fmt.Sprintf("deploy/environment/%d/variables/%s", environmentID, url.PathEscape(name))
+	request, err := d.client.NewRequest(http.MethodDelete, u, nil)
+	if err != nil {
+		return err
+	}
+
+	response, err := d.client.Do(request, nil)
+	if err != nil {
+		return err
+	}
+
+	if response.StatusCode != http.StatusOK && response.StatusCode != http.StatusNoContent {
+		return newRespErr(response, "Error deleting environment variable")
+	}
+
+	return nil
+}
+
+// -- Requirements --
+
+// EnvironmentRequirements returns the agent requirements of the given environment
+func (d *DeployService) EnvironmentRequirements(environmentID int) ([]*Requirement, error) {
+	request, err := d.client.NewRequest(http.MethodGet, fmt.Sprintf("deploy/environment/%d/requirement", environmentID), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	requirements := []*Requirement{}
+	response, err := d.client.Do(request, &requirements)
+	if err != nil {
+		return nil, err
+	}
+
+	if response.StatusCode != http.StatusOK {
+		return nil, newRespErr(response, "Error getting environment requirements")
+	}
+
+	return requirements, nil
+}
+
+// CreateEnvironmentRequirement adds an agent requirement to the given environment and returns it with its id set
+func (d *DeployService) CreateEnvironmentRequirement(environmentID int, requirement *Requirement) (*Requirement, error) {
+	request, err := d.client.NewRequest(http.MethodPost, fmt.Sprintf("deploy/environment/%d/requirement", environmentID), requirement)
+	if err != nil {
+		return nil, err
+	}
+
+	created := &Requirement{}
+	response, err := d.client.Do(request, created)
+	if err != nil {
+		return nil, err
+	}
+
+	if response.StatusCode != http.StatusOK && response.StatusCode != http.StatusCreated {
+		return nil, newRespErr(response, "Error creating environment requirement")
+	}
+
+	return created, nil
+}
+
+// UpdateEnvironmentRequirement replaces the requirement with the same id on the given environment
+func (d *DeployService) UpdateEnvironmentRequirement(environmentID int, requirement *Requirement) (*Requirement, error) {
+	u := fmt.Sprintf("deploy/environment/%d/requirement/%d", environmentID, requirement.ID)
+	request, err := d.client.NewRequest(http.MethodPut, u, requirement)
+	if err != nil {
+		return nil, err
+	}
+
+	updated := &Requirement{}
+	response, err := d.client.Do(request, updated)
+	if err != nil {
+		return nil, err
+	}
+
+	if response.StatusCode != http.StatusOK {
+		return nil, newRespErr(response, "Error updating environment requirement")
+	}
+
+	return updated, nil
+}
+
+// DeleteEnvironmentRequirement removes the requirement with the given id from the environment
+func (d *DeployService) DeleteEnvironmentRequirement(environmentID, requirementID int) error {
+	u := fmt.Sprintf("deploy/environment/%d/requirement/%d", environmentID, requirementID)
+	request, err := d.client.NewRequest(http.MethodDelete, u, nil)
+	if err != nil {
+		return err
+	}
+
+	response, err := d.client.Do(request, nil)
+	if err != nil {
+		return err
+	}
+
+	if response.StatusCode != http.StatusOK && response.StatusCode != http.StatusNoContent {
+		return newRespErr(response, "Error deleting environment requirement")
+	}
+
+	return nil
+}
+
+// -- Triggers --
+
+// EnvironmentTriggers returns the triggers configured on the given environment
+func (d *DeployService) EnvironmentTriggers(environmentID int) ([]*EnvironmentTrigger, error) {
+	request, err := d.client.NewRequest(http.MethodGet, fmt.Sprintf("deploy/environment/%d/trigger", environmentID), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	triggers := []*EnvironmentTrigger{}
+	response, err := d.client.Do(request, &triggers)
+	if err != nil {
+		return nil, err
+	}
+
+	if response.StatusCode != http.StatusOK {
+		return nil, newRespErr(response, "Error getting environment triggers")
+	}
+
+	return triggers, nil
+}
+
+// CreateEnvironmentTrigger adds a trigger to the given environment and returns it with its id set
+func (d *DeployService) CreateEnvironmentTrigger(environmentID int, trigger *EnvironmentTrigger) (*EnvironmentTrigger, error) {
+	request, err := d.client.NewRequest(http.MethodPost, fmt.Sprintf("deploy/environment/%d/trigger", environmentID), trigger)
+	if err != nil {
+		return nil, err
+	}
+
+	created := &EnvironmentTrigger{}
+	response, err := d.client.Do(request, created)
+	if err != nil {
+		return nil, err
+	}
+
+	if response.StatusCode != http.StatusOK && response.StatusCode != http.StatusCreated {
+		return nil, newRespErr(response, "Error creating environment trigger")
+	}
+
+	return created, nil
+}
+
+// UpdateEnvironmentTrigger replaces the trigger with the same id on the given environment
+func (d *DeployService) UpdateEnvironmentTrigger(environmentID int, trigger *EnvironmentTrigger) (*EnvironmentTrigger, error) {
+	u := fmt.Sprintf("deploy/environment/%d/trigger/%d", environmentID, trigger.ID)
+	request, err := d.client.NewRequest(http.MethodPut, u, trigger)
+	if err != nil {
+		return nil, err
+	}
+
+	updated := &EnvironmentTrigger{}
+	response, err := d.client.Do(request, updated)
+	if err != nil {
+		return nil, err
+	}
+
+	if response.StatusCode != http.StatusOK {
+		return nil, newRespErr(response, "Error updating environment trigger")
+	}
+
+	return updated, nil
+}
+
+// DeleteEnvironmentTrigger removes the trigger with the given id from the environment
+func (d *DeployService) DeleteEnvironmentTrigger(environmentID, triggerID int) error {
+	u := fmt.Sprintf("deploy/environment/%d/trigger/%d", environmentID, triggerID)
+	request, err := d.client.NewRequest(http.MethodDelete, u, nil)
+	if err != nil {
+		return err
+	}
+
+	response, err := d.client.Do(request, nil)
+	if err != nil {
+		return err
+	}
+
+	if response.StatusCode != http.StatusOK && response.StatusCode != http.StatusNoContent {
+		return newRespErr(response, "Error deleting environment trigger")
+	}
+
+	return nil
+}
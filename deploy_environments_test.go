@@ -0,0 +1,128 @@
+package bamboo_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	bamboo "github.com/rcarmstrong/go-bamboo"
+)
+
+func TestEnvironmentConfig(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(environmentConfigStub))
+	defer ts.Close()
+
+	client := bamboo.NewSimpleClient(nil, "", "")
+	client.SetURL(ts.URL)
+
+	config, err := client.Deploys.EnvironmentConfig(10)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, len(config.Variables))
+	assert.True(t, config.Variables[2].Masked())
+	assert.Equal(t, 1, len(config.Requirements))
+	assert.Equal(t, bamboo.RequirementExists, config.Requirements[0].MatchType)
+	assert.Equal(t, 1, len(config.Triggers))
+
+	desired := []*bamboo.Variable{{Name: "region", Value: "us-west-2"}, {Name: "replicas", Value: "3"}, {Name: "db.password", Value: "hunter2"}}
+	diff := bamboo.DiffVariables(desired, config.Variables)
+	assert.Equal(t, "replicas", diff.Missing[0].Name)
+	assert.Equal(t, 1, len(diff.Changed))
+	assert.Equal(t, "region", diff.Changed[0].Name)
+	assert.Equal(t, "tier", diff.Extra[0].Name)
+
+	// Masked passwords can't be compared so aren't reported as changed
+	assert.Equal(t, "db.password", diff.Masked[0].Name)
+	diff = bamboo.DiffVariables(desired[2:], config.Variables[2:])
+	assert.True(t, diff.Empty())
+}
+
+func environmentConfigStub(w http.ResponseWriter, r *http.Request) {
+	switch r.URL.Path {
+	case "/rest/api/latest/deploy/environment/10/variables":
+		fmt.Fprint(w, `[{"name":"region","value":"us-east-1"},{"name":"tier","value":"prod"},{"name":"db.password","value":"********"}]`)
+	case "/rest/api/latest/deploy/environment/10/requirement":
+		fmt.Fprint(w, `[{"id":5,"key":"system.docker.executable","matchType":"EXISTS"}]`)
+	case "/rest/api/latest/deploy/environment/10/trigger":
+		fmt.Fprint(w, `[{"id":7,"name":"After successful build plan","pluginKey":"com.atlassian.bamboo.triggers.atlassian-bamboo-triggers:afterSuccessfulPlan","enabled":true}]`)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func TestCreateEnvironmentVariable(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(createEnvironmentVariableStub))
+	defer ts.Close()
+
+	client := bamboo.NewSimpleClient(nil, "", "")
+	client.SetURL(ts.URL)
+
+	err := client.Deploys.CreateEnvironmentVariable(10, &bamboo.Variable{Name: "region", Value: "us-east-1"})
+	assert.NoError(t, err)
+}
+
+func createEnvironmentVariableStub(w http.ResponseWriter, r *http.Request) {
+	variable := &bamboo.Variable{}
+	json.NewDecoder(r.Body).Decode(variable)
+
+	if r.Method != http.MethodPost || r.URL.Path != "/rest/api/latest/deploy/environment/10/variables" || variable.Name != "region" {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	w.WriteHeader(http.StatusCreated)
+}
+
+func TestEnvironmentConfigChanges(t *testing.T) {
+	var requests []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+strings.TrimPrefix(r.URL.Path, "/rest/api/latest/"))
+		switch {
+		case r.Method == http.MethodDelete:
+			w.WriteHeader(http.StatusNoContent)
+		case strings.HasSuffix(r.URL.Path, "/requirement/5"):
+			fmt.Fprint(w, `{"id":5,"key":"system.docker.executable","matchType":"EQUALS","matchValue":"/usr/bin/docker"}`)
+		case strings.HasSuffix(r.URL.Path, "/trigger/7"):
+			fmt.Fprint(w, `{"id":7,"name":"Nightly","enabled":false}`)
+		case strings.HasSuffix(r.URL.Path, "/variables/region"):
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	client := bamboo.NewSimpleClient(nil, "", "")
+	client.SetURL(ts.URL)
+
+	assert.NoError(t, client.Deploys.UpdateEnvironmentVariable(10, &bamboo.Variable{Name: "region", Value: "us-west-2"}))
+	assert.NoError(t, client.Deploys.DeleteEnvironmentVariable(10, "region"))
+
+	requirement, err := client.Deploys.UpdateEnvironmentRequirement(10, &bamboo.Requirement{ID: 5, Key: "system.docker.executable", MatchType: bamboo.RequirementEquals, MatchValue: "/usr/bin/docker"})
+	assert.NoError(t, err)
+	assert.Equal(t, "/usr/bin/docker", requirement.MatchValue)
+	assert.NoError(t, client.Deploys.DeleteEnvironmentRequirement(10, 5))
+
+	trigger, err := client.Deploys.UpdateEnvironmentTrigger(10, &bamboo.EnvironmentTrigger{ID: 7, Name: "Nightly"})
+	assert.NoError(t, err)
+	assert.False(t, trigger.Enabled)
+	assert.NoError(t, client.Deploys.DeleteEnvironmentTrigger(10, 7))
+
+	assert.Equal(t, []string{
+		"PUT deploy/environment/10/variables/region",
+		"DELETE deploy/environment/10/variables/region",
+		"PUT deploy/environment/10/requirement/5",
+		"DELETE deploy/environment/10/requirement/5",
+		"PUT deploy/environment/10/trigger/7",
+		"DELETE deploy/environment/10/trigger/7",
+	}, requests)
+
+	// Errors from the server are returned
+	err = client.Deploys.UpdateEnvironmentVariable(10, &bamboo.Variable{Name: "missing"})
+	assert.Error(t, err)
+	_, err = client.Deploys.UpdateEnvironmentTrigger(10, &bamboo.EnvironmentTrigger{ID: 99})
+	assert.Error(t, err)
+}
@@ -0,0 +1,228 @@
+package bamboo
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+)
+
+// versionsPageSize is the number of deployment versions requested per page when searching versions
+const versionsPageSize = 100
+
+// resultNumberSuffix matches the build number at the end of a build result key
+var resultNumberSuffix = regexp.MustCompile(`-\d+$`)
+
+// planBranchInfo is the part of a plan needed to tell a plan branch from its master plan
+type planBranchInfo struct {
+	Key    string   `json:"key"`
+	Master *PlanKey `json:"master,omitempty"`
+}
+
+// ResultDeployment is a deployment version created from a build result together
+// with the environments the version is currently deployed to
+type ResultDeployment struct {
+	Project      *Deploy
+	Version      *DeployVersionResult
+	Environments []*DeployEnvironment
+}
+
+// Deploy returns the deployment project with the given id, including its environments
+func (d *DeployService) Deploy(deploymentProjectID int) (*Deploy, error) {
+	request, err := d.client.NewRequest(http.MethodGet, fmt.Sprintf("deploy/project/%d", deploymentProjectID), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	deploy := &Deploy{}
+	response, err := d.client.Do(request, deploy)
+	if err != nil {
+		return nil, err
+	}
+
+	if response.StatusCode != http.StatusOK {
+		return nil, newRespErr(response, "Error getting deploy project")
+	}
+
+	return deploy, nil
+}
+
+// DeploysForPlan returns the deployment projects that use the given build plan as their source
+func (d *DeployService) DeploysForPlan(planKey string) (DeploysResponse, error) {
+	if emptyStrings(planKey) {
+		return nil, &simpleError{"Plan key cannot be an empty string"}
+	}
+
+	request, err := d.client.NewRequest(http.MethodGet, "deploy/project/forPlan", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	values := request.URL.Query()
+	values.Set("planKey", planKey)
+	request.URL.RawQuery = values.Encode()
+
+	deployResp := DeploysResponse{}
+	response, err := d.client.Do(request, &deployResp)
+	if err != nil {
+		return nil, err
+	}
+
+	if response.StatusCode != http.StatusOK {
+		return nil, newRespErr(response, "Error listing deploys for plan")
+	}
+
+	return deployResp, nil
+}
+
+// ListDeployVersions returns a page of the versions created for the given deployment project, newest first
+func (d *DeployService) ListDeployVersions(deploymentProjectID int, page *Pagination) (*DeployVersionListResult, error) {
+	request, err := d.client.NewRequest(http.MethodGet, fmt.Sprintf("deploy/project/%d/versions", deploymentProjectID), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	values := request.URL.Query()
+	page.setQuery(values)
+	request.URL.RawQuery = values.Encode()
+
+	versions := &DeployVersionListResult{}
+	response, err := d.client.Do(request, versions)
+	if err != nil {
+		return nil, err
+	}
+
+	if response.StatusCode != http.StatusOK {
+		return nil, newRespErr(response, "Error listing deploy versions")
+	}
+
+	return versions, nil
+}
+
+// CurrentDeployment returns the most recent successful deployment to the given environment.
+// A nil result is returned if nothing has been successfully deployed to the environment.
+func (d *DeployService) CurrentDeployment(environmentID int) (*DeploymentResult, error) {
+	page := &Pagination{Limit: historyPageSize}
+	for {
+		history, err := d.DeployEnvironmentHistory(environmentID, page)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, result := range history.Results {
+			if result.Successful() {
+				return result, nil
+			}
+		}
+
+		if !page.advance(len(history.Results), history.CollectionMetadata) {
+			return nil, nil
+		}
+	}
+}
+
+// DeploymentsForResult returns the deployment versions created from the given build result key
+// (e.g. "PROJ-PLAN-42") and the environments each of them is currently deployed to. Results of a
+// plan branch (e.g. "PROJ-PLAN0-3") are looked up in the deployment projects of its master plan.
+func (d *DeployService) DeploymentsForResult(buildResultKey string) ([]*ResultDeployment, error) {
+	planKey := resultNumberSuffix.ReplaceAllString(buildResultKey, "")
+	if emptyStrings(planKey) || planKey == buildResultKey {
+		return nil, &simpleError{fmt.Sprintf("%q is not a build result key", buildResultKey)}
+	}
+
+	// Deployment projects are linked to the master plan, never to one of its branches
+	planKey, err := d.masterPlanKey(planKey)
+	if err != nil {
+		return nil, err
+	}
+
+	deploys, err := d.DeploysForPlan(planKey)
+	if err != nil {
+		return nil, err
+	}
+
+	deployments := []*ResultDeployment{}
+	for _, deploy := range deploys {
+		versions, err := d.versionsForResult(deploy.ID, buildResultKey)
+		if err != nil {
+			return nil, err
+		}
+		if len(versions) == 0 {
+			continue
+		}
+
+		project, err := d.Deploy(deploy.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		current := map[int][]*DeployEnvironment{}
+		for _, environment := range project.Environments {
+			result, err := d.CurrentDeployment(environment.ID)
+			if err != nil {
+				return nil, err
+			}
+			if result != nil && result.DeploymentVersion != nil {
+				current[result.DeploymentVersion.ID] = append(current[result.DeploymentVersion.ID], environment)
+			}
+		}
+
+		for _, version := range versions {
+			deployments = append(deployments, &ResultDeployment{
+				Project:      project,
+				Version:      version,
+				Environments: current[version.ID],
+			})
+		}
+	}
+
+	return deployments, nil
+}
+
+// masterPlanKey returns the key of the master plan of the given plan branch, or planKey itself if it isn't a branch
+func (d *DeployService) masterPlanKey(planKey string) (string, error) {
+	request, err := d.client.NewRequest(http.MethodGet, fmt.Sprintf("plan/%s", planKey), nil)
+	if err != nil {
+		return "", err
+	}
+
+	plan := &planBranchInfo{}
+	response, err := d.client.Do(request, plan)
+	if err != nil {
+		return "", err
+	}
+
+	if response.StatusCode != http.StatusOK {
+		return "", newRespErr(response, "Error getting plan "+planKey)
+	}
+
+	if plan.Master != nil && plan.Master.Key != "" {
+		return plan.Master.Key, nil
+	}
+	return planKey, nil
+}
+
+// versionsForResult pages through the versions of a deployment project and returns those created from the given build result
+func (d *DeployService) versionsForResult(deploymentProjectID int, buildResultKey string) ([]*DeployVersionResult, error) {
+	matches := []*DeployVersionResult{}
+
+	page := &Pagination{Limit: versionsPageSize}
+	for {
+		versions, err := d.ListDeployVersions(deploymentProjectID, page)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, version := range versions.Versions {
+			for _, item := range version.Items {
+				if item.PlanResultKey != nil && item.PlanResultKey.Key == buildResultKey {
+					matches = append(matches, version)
+					break
+				}
+			}
+		}
+
+		if !page.advance(len(versions.Versions), versions.CollectionMetadata) {
+			return matches, nil
+		}
+	}
+}
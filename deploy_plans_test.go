@@ -0,0 +1,84 @@
+package bamboo_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	bamboo "github.com/rcarmstrong/go-bamboo"
+)
+
+func TestDeploysForPlan(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(deploymentsForResultStub))
+	defer ts.Close()
+
+	client := bamboo.NewSimpleClient(nil, "", "")
+	client.SetURL(ts.URL)
+
+	deploys, err := client.Deploys.DeploysForPlan("CORE-TEST")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(deploys))
+	assert.Equal(t, 3, deploys[0].ID)
+
+	_, err = client.Deploys.DeploysForPlan("")
+	assert.Error(t, err)
+}
+
+func TestDeploymentsForResult(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(deploymentsForResultStub))
+	defer ts.Close()
+
+	client := bamboo.NewSimpleClient(nil, "", "")
+	client.SetURL(ts.URL)
+
+	deployments, err := client.Deploys.DeploymentsForResult("CORE-TEST-12")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(deployments))
+	assert.Equal(t, "release-12", deployments[0].Version.Name)
+	assert.Equal(t, 1, len(deployments[0].Environments))
+	assert.Equal(t, "staging", deployments[0].Environments[0].Name)
+
+	// Branch results are found through the deployment projects of the master plan
+	deployments, err = client.Deploys.DeploymentsForResult("CORE-TEST0-11")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(deployments))
+	assert.Equal(t, "release-11", deployments[0].Version.Name)
+	assert.Equal(t, "prod", deployments[0].Environments[0].Name)
+
+	_, err = client.Deploys.DeploymentsForResult("CORE-TEST")
+	assert.Error(t, err)
+	_, err = client.Deploys.DeploymentsForResult("CORE-GONE-1")
+	assert.Error(t, err)
+}
+
+func deploymentsForResultStub(w http.ResponseWriter, r *http.Request) {
+	switch r.URL.Path {
+	case "/rest/api/latest/plan/CORE-TEST":
+		fmt.Fprint(w, `{"key":"CORE-TEST","type":"chain"}`)
+	case "/rest/api/latest/plan/CORE-TEST0":
+		fmt.Fprint(w, `{"key":"CORE-TEST0","type":"chain_branch","master":{"key":"CORE-TEST"}}`)
+	case "/rest/api/latest/deploy/project/forPlan":
+		if r.URL.Query().Get("planKey") != "CORE-TEST" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		fmt.Fprint(w, `[{"id":3,"name":"Core"}]`)
+	case "/rest/api/latest/deploy/project/3/versions":
+		fmt.Fprint(w, `{"size":2,"versions":[
+			{"id":12,"name":"release-12","items":[{"id":1,"planResultKey":{"key":"CORE-TEST-12","resultNumber":12}}]},
+			{"id":11,"name":"release-11","items":[{"id":2,"planResultKey":{"key":"CORE-TEST0-11","resultNumber":11}}]}]}`)
+	case "/rest/api/latest/deploy/project/3":
+		fmt.Fprint(w, `{"id":3,"name":"Core","environments":[{"id":20,"name":"staging"},{"id":21,"name":"prod"}]}`)
+	case "/rest/api/latest/deploy/environment/20/results":
+		fmt.Fprint(w, `{"id":20,"size":1,"results":[{"id":5,"deploymentVersion":{"id":12},"deploymentState":"SUCCESS","lifeCycleState":"FINISHED"}]}`)
+	case "/rest/api/latest/deploy/environment/21/results":
+		fmt.Fprint(w, `{"id":21,"size":2,"results":[
+			{"id":7,"deploymentVersion":{"id":12},"deploymentState":"FAILED","lifeCycleState":"FINISHED"},
+			{"id":6,"deploymentVersion":{"id":11},"deploymentState":"SUCCESS","lifeCycleState":"FINISHED"}]}`)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
@@ -0,0 +1,198 @@
+package bamboo
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// Deployment states reported by Bamboo for a finished deployment result
+const (
+	DeploymentSuccessful = "SUCCESS"
+	DeploymentFailed     = "FAILED"
+	DeploymentUnknown    = "UNKNOWN"
+)
+
+// defaultLogPageSize is the number of log entries requested per page when downloading a deployment log
+const defaultLogPageSize = 1000
+
+// historyPageSize is the number of deployment results requested per page when searching an environment's history
+const historyPageSize = 25
+
+// DeploymentHistory is a page of deployment results for a single environment
+type DeploymentHistory struct {
+	*CollectionMetadata
+	Name    string              `json:"name"`
+	ID      int                 `json:"id"`
+	Results []*DeploymentResult `json:"results"`
+}
+
+// DeploymentResult is a single deployment of a version to an environment.
+// Timestamps are converted from the millisecond epoch values the API returns.
+type DeploymentResult struct {
+	ID                    int                `json:"id"`
+	DeploymentVersion     *DeploymentVersion `json:"deploymentVersion"`
+	DeploymentVersionName string             `json:"deploymentVersionName"`
+	DeploymentState       string             `json:"deploymentState"`
+	LifeCycleState        string             `json:"lifeCycleState"`
+	QueuedDate            time.Time          `json:"queuedDate"`
+	StartedDate           time.Time          `json:"startedDate"`
+	ExecutedDate          time.Time          `json:"executedDate"`
+	FinishedDate          time.Time          `json:"finishedDate"`
+	ReasonSummary         string             `json:"reasonSummary"`
+	Agent                 *Agent             `json:"agent,omitempty"`
+	LogEntries            *DeploymentLog     `json:"logEntries,omitempty"`
+}
+
+// UnmarshalJSON decodes a deployment result, converting its epoch timestamps to time.Time
+func (r *DeploymentResult) UnmarshalJSON(data []byte) error {
+	type alias DeploymentResult
+	aux := struct {
+		*alias
+		QueuedDate   epochTime `json:"queuedDate"`
+		StartedDate  epochTime `json:"startedDate"`
+		ExecutedDate epochTime `json:"executedDate"`
+		FinishedDate epochTime `json:"finishedDate"`
+	}{alias: (*alias)(r)}
+
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	r.QueuedDate = aux.QueuedDate.Time
+	r.StartedDate = aux.StartedDate.Time
+	r.ExecutedDate = aux.ExecutedDate.Time
+	r.FinishedDate = aux.FinishedDate.Time
+	return nil
+}
+
+// Successful reports whether the deployment finished and was successful
+func (r *DeploymentResult) Successful() bool {
+	return r.LifeCycleState == "FINISHED" && r.DeploymentState == DeploymentSuccessful
+}
+
+// DeploymentLog is a page of log entries for a deployment result
+type DeploymentLog struct {
+	*CollectionMetadata
+	Entries []*DeploymentLogEntry `json:"logEntry"`
+}
+
+// DeploymentLogEntry is a single line of a deployment log
+type DeploymentLogEntry struct {
+	Log         string    `json:"log"`
+	UnstyledLog string    `json:"unstyledLog"`
+	Date        time.Time `json:"date"`
+}
+
+// UnmarshalJSON decodes a log entry, converting its epoch timestamp to time.Time
+func (e *DeploymentLogEntry) UnmarshalJSON(data []byte) error {
+	type alias DeploymentLogEntry
+	aux := struct {
+		*alias
+		Date epochTime `json:"date"`
+	}{alias: (*alias)(e)}
+
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	e.Date = aux.Date.Time
+	return nil
+}
+
+// DeployEnvironmentHistory returns a page of deployment results for the given environment, newest first.
+// A nil page returns the server's default page.
+func (d *DeployService) DeployEnvironmentHistory(environmentID int, page *Pagination) (*DeploymentHistory, error) {
+	request, err := d.client.NewRequest(http.MethodGet, fmt.Sprintf("deploy/environment/%d/results", environmentID), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	values := request.URL.Query()
+	page.setQuery(values)
+	request.URL.RawQuery = values.Encode()
+
+	history := &DeploymentHistory{}
+	response, err := d.client.Do(request, history)
+	if err != nil {
+		return nil, err
+	}
+
+	if response.StatusCode != http.StatusOK {
+		return nil, newRespErr(response, "Error getting deploy history")
+	}
+
+	return history, nil
+}
+
+// DeploymentResult returns the deployment result with the given id
+func (d *DeployService) DeploymentResult(deploymentResultID int) (*DeploymentResult, error) {
+	request, err := d.client.NewRequest(http.MethodGet, fmt.Sprintf("deploy/result/%d", deploymentResultID), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &DeploymentResult{}
+	response, err := d.client.Do(request, result)
+	if err != nil {
+		return nil, err
+	}
+
+	if response.StatusCode != http.StatusOK {
+		return nil, newRespErr(response, "Error getting deployment result")
+	}
+
+	return result, nil
+}
+
+// DeploymentLog returns a page of log entries for the given deployment result
+func (d *DeployService) DeploymentLog(deploymentResultID int, page *Pagination) (*DeploymentLog, error) {
+	request, err := d.client.NewRequest(http.MethodGet, fmt.Sprintf("deploy/result/%d", deploymentResultID), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	values := request.URL.Query()
+	values.Set("includeLogs", "true")
+	page.setQuery(values)
+	request.URL.RawQuery = values.Encode()
+
+	result := &DeploymentResult{}
+	response, err := d.client.Do(request, result)
+	if err != nil {
+		return nil, err
+	}
+
+	if response.StatusCode != http.StatusOK {
+		return nil, newRespErr(response, "Error getting deployment log")
+	}
+
+	if result.LogEntries == nil {
+		return &DeploymentLog{}, nil
+	}
+
+	return result.LogEntries, nil
+}
+
+// DownloadDeploymentLog writes the full unstyled log of the given deployment result to w, one entry per line
+func (d *DeployService) DownloadDeploymentLog(deploymentResultID int, w io.Writer) error {
+	page := &Pagination{Limit: defaultLogPageSize}
+	for {
+		log, err := d.DeploymentLog(deploymentResultID, page)
+		if err != nil {
+			return err
+		}
+
+		for _, entry := range log.Entries {
+			if _, err := io.WriteString(w, entry.UnstyledLog+"\n"); err != nil {
+				return err
+			}
+		}
+
+		if !page.advance(len(log.Entries), log.CollectionMetadata) {
+			return nil
+		}
+	}
+}
@@ -0,0 +1,79 @@
+package bamboo_test
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	bamboo "github.com/rcarmstrong/go-bamboo"
+)
+
+func TestDeployEnvironmentHistory(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(deployEnvironmentHistoryStub))
+	defer ts.Close()
+
+	client := bamboo.NewSimpleClient(nil, "", "")
+	client.SetURL(ts.URL)
+
+	history, err := client.Deploys.DeployEnvironmentHistory(10, &bamboo.Pagination{Start: 25, Limit: 25})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(history.Results))
+
+	result := history.Results[0]
+	assert.Equal(t, 77, result.ID)
+	assert.True(t, result.Successful())
+	assert.Equal(t, int64(1546300800000), result.StartedDate.UnixNano()/1e6)
+	assert.Equal(t, int64(1546300860000), result.FinishedDate.UnixNano()/1e6)
+	assert.Equal(t, "Manual run by admin", result.ReasonSummary)
+	assert.Equal(t, "Default Agent", result.Agent.Name)
+}
+
+func deployEnvironmentHistoryStub(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != "/rest/api/latest/deploy/environment/10/results" ||
+		r.URL.Query().Get("start-index") != "25" || r.URL.Query().Get("max-result") != "25" {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	fmt.Fprint(w, `{"name":"prod","id":10,"size":26,"start-index":25,"max-result":25,"results":[
+		{"id":77,"deploymentVersionName":"release-1","deploymentState":"SUCCESS","lifeCycleState":"FINISHED",
+		 "startedDate":1546300800000,"finishedDate":1546300860000,"reasonSummary":"Manual run by admin",
+		 "agent":{"id":1,"name":"Default Agent","type":"LOCAL"}}]}`)
+}
+
+func TestDownloadDeploymentLog(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(deploymentLogStub))
+	defer ts.Close()
+
+	client := bamboo.NewSimpleClient(nil, "", "")
+	client.SetURL(ts.URL)
+
+	buf := &bytes.Buffer{}
+	err := client.Deploys.DownloadDeploymentLog(77, buf)
+	assert.NoError(t, err)
+	assert.Equal(t, "starting\nworking\ndone\n", buf.String())
+}
+
+func deploymentLogStub(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != "/rest/api/latest/deploy/result/77" || r.URL.Query().Get("includeLogs") != "true" {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	// The server caps pages at two entries, fewer than the client asks for
+	switch r.URL.Query().Get("start-index") {
+	case "0":
+		fmt.Fprint(w, `{"id":77,"logEntries":{"size":3,"start-index":0,"max-result":2,"logEntry":[
+			{"unstyledLog":"starting","log":"<b>starting</b>","date":1546300800000},
+			{"unstyledLog":"working","log":"working","date":1546300830000}]}}`)
+	case "2":
+		fmt.Fprint(w, `{"id":77,"logEntries":{"size":3,"start-index":2,"max-result":2,"logEntry":[
+			{"unstyledLog":"done","log":"done","date":1546300860000}]}}`)
+	default:
+		w.WriteHeader(http.StatusBadRequest)
+	}
+}
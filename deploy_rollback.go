@@ -0,0 +1,127 @@
+package bamboo
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// Version states a deployment version can be marked with
+const (
+	VersionApproved = "APPROVED"
+	VersionBroken   = "BROKEN"
+	VersionUnknown  = "UNKNOWN"
+)
+
+// DeploymentVersionStatus is the approval state a user marked a deployment version with
+type DeploymentVersionStatus struct {
+	UserName     string `json:"userName"`
+	VersionState string `json:"versionState"`
+}
+
+// RollbackOptions specifies the optional parameters for the Rollback and RollbackVersion methods
+type RollbackOptions struct {
+	// SkipBroken ignores versions that have been marked as broken
+	SkipBroken bool
+	// SkipVersions ignores the versions with the given ids
+	SkipVersions []int
+}
+
+func (o *RollbackOptions) skipped(versionID int) bool {
+	if o == nil {
+		return false
+	}
+	for _, id := range o.SkipVersions {
+		if id == versionID {
+			return true
+		}
+	}
+	return false
+}
+
+// DeployVersion returns the deployment version with the given id
+func (d *DeployService) DeployVersion(versionID int) (*DeploymentVersion, error) {
+	request, err := d.client.NewRequest(http.MethodGet, fmt.Sprintf("deploy/version/%d", versionID), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	version := &DeploymentVersion{}
+	response, err := d.client.Do(request, version)
+	if err != nil {
+		return nil, err
+	}
+
+	if response.StatusCode != http.StatusOK {
+		return nil, newRespErr(response, "Error getting deploy version")
+	}
+
+	return version, nil
+}
+
+// RollbackVersion finds the most recent version that was successfully deployed to the given
+// environment before the version that is currently deployed.
+func (d *DeployService) RollbackVersion(environmentID int, opts *RollbackOptions) (*DeploymentVersion, error) {
+	currentVersionID := 0
+	checked := map[int]bool{}
+
+	page := &Pagination{Limit: historyPageSize}
+	for {
+		history, err := d.DeployEnvironmentHistory(environmentID, page)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, result := range history.Results {
+			if result.DeploymentVersion == nil {
+				continue
+			}
+			versionID := result.DeploymentVersion.ID
+
+			// The newest result holds the version being rolled back from
+			if currentVersionID == 0 {
+				currentVersionID = versionID
+				continue
+			}
+
+			if versionID == currentVersionID || checked[versionID] || !result.Successful() || opts.skipped(versionID) {
+				continue
+			}
+			checked[versionID] = true
+
+			if opts != nil && opts.SkipBroken {
+				version, err := d.DeployVersion(versionID)
+				if err != nil {
+					return nil, err
+				}
+				if version.VersionStatus != nil && version.VersionStatus.VersionState == VersionBroken {
+					continue
+				}
+				return version, nil
+			}
+
+			return result.DeploymentVersion, nil
+		}
+
+		if !page.advance(len(history.Results), history.CollectionMetadata) {
+			break
+		}
+	}
+
+	return nil, &simpleError{fmt.Sprintf("No previous successful deployment found for environment %d", environmentID)}
+}
+
+// Rollback queues a deployment of the version returned by RollbackVersion to the given environment
+// and returns the resulting deployment.
+func (d *DeployService) Rollback(environmentID int, opts *RollbackOptions) (*DeploymentResult, error) {
+	version, err := d.RollbackVersion(environmentID, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	queued, err := d.QueueDeploy(environmentID, version.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return d.DeploymentResult(queued.DeploymentResultID)
+}
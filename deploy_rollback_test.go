@@ -0,0 +1,70 @@
+package bamboo_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	bamboo "github.com/rcarmstrong/go-bamboo"
+)
+
+func TestRollbackVersion(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(rollbackStub))
+	defer ts.Close()
+
+	client := bamboo.NewSimpleClient(nil, "", "")
+	client.SetURL(ts.URL)
+
+	version, err := client.Deploys.RollbackVersion(10, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, version.ID)
+
+	version, err = client.Deploys.RollbackVersion(10, &bamboo.RollbackOptions{SkipBroken: true})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, version.ID)
+
+	version, err = client.Deploys.RollbackVersion(10, &bamboo.RollbackOptions{SkipVersions: []int{1, 2}})
+	assert.Error(t, err)
+	assert.Nil(t, version)
+}
+
+func TestRollback(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(rollbackStub))
+	defer ts.Close()
+
+	client := bamboo.NewSimpleClient(nil, "", "")
+	client.SetURL(ts.URL)
+
+	result, err := client.Deploys.Rollback(10, &bamboo.RollbackOptions{SkipBroken: true})
+	assert.NoError(t, err)
+	assert.Equal(t, 99, result.ID)
+	assert.Equal(t, 1, result.DeploymentVersion.ID)
+}
+
+func rollbackStub(w http.ResponseWriter, r *http.Request) {
+	switch r.URL.Path {
+	case "/rest/api/latest/deploy/environment/10/results":
+		fmt.Fprint(w, `{"id":10,"size":4,"results":[
+			{"id":4,"deploymentVersion":{"id":3,"name":"v3"},"deploymentState":"FAILED","lifeCycleState":"FINISHED"},
+			{"id":3,"deploymentVersion":{"id":3,"name":"v3"},"deploymentState":"SUCCESS","lifeCycleState":"FINISHED"},
+			{"id":2,"deploymentVersion":{"id":2,"name":"v2"},"deploymentState":"SUCCESS","lifeCycleState":"FINISHED"},
+			{"id":1,"deploymentVersion":{"id":1,"name":"v1"},"deploymentState":"SUCCESS","lifeCycleState":"FINISHED"}]}`)
+	case "/rest/api/latest/deploy/version/2":
+		fmt.Fprint(w, `{"id":2,"name":"v2","versionStatus":{"userName":"admin","versionState":"BROKEN"}}`)
+	case "/rest/api/latest/deploy/version/1":
+		fmt.Fprint(w, `{"id":1,"name":"v1","versionStatus":{"userName":"admin","versionState":"APPROVED"}}`)
+	case "/rest/api/latest/queue/deployment/":
+		if r.Method != http.MethodPost || r.URL.Query().Get("versionId") != "1" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		fmt.Fprint(w, `{"deploymentResultId":99}`)
+	case "/rest/api/latest/deploy/result/99":
+		fmt.Fprint(w, `{"id":99,"deploymentVersion":{"id":1,"name":"v1"},"lifeCycleState":"QUEUED"}`)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
@@ -42,8 +42,9 @@ type DeployEnvironmentResults struct {
 
 // DeploymentVersion contains version information for a deployment
 type DeploymentVersion struct {
-	Name string `json:"name"`
-	ID   int    `json:"id"`
+	Name          string                   `json:"name"`
+	ID            int                      `json:"id"`
+	VersionStatus *DeploymentVersionStatus `json:"versionStatus,omitempty"`
 }
 
 // QueueDeployRequest contains information from a queue deploy request
@@ -70,12 +71,29 @@ type createDeploymentVersion struct {
 // DeployVersionResult will have the information for creating a
 // new release/version for bamboo
 type DeployVersionResult struct {
-	ID   int    `json:"id"`
-	Name string `json:"name"`
+	ID             int                      `json:"id"`
+	Name           string                   `json:"name"`
+	PlanBranchName string                   `json:"planBranchName,omitempty"`
+	Items          []*DeploymentVersionItem `json:"items,omitempty"`
+}
+
+// DeploymentVersionItem is an artifact source of a deployment version, linking it to the build result it was created from
+type DeploymentVersionItem struct {
+	ID            int            `json:"id"`
+	Name          string         `json:"name"`
+	PlanResultKey *PlanResultKey `json:"planResultKey"`
+}
+
+// PlanResultKey identifies a single build result
+type PlanResultKey struct {
+	Key          string   `json:"key"`
+	EntityKey    *PlanKey `json:"entityKey,omitempty"`
+	ResultNumber int      `json:"resultNumber"`
 }
 
 // DeployVersionListResult stores a list of deployment versions
 type DeployVersionListResult struct {
+	*CollectionMetadata
 	Versions []*DeployVersionResult `json:"versions"`
 }
 
@@ -0,0 +1,183 @@
+package bamboo
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// ElasticPending is the state of an elastic instance that has been requested but isn't running yet
+const ElasticPending string = "PENDING"
+
+// ElasticRunning is the state of a running elastic instance
+const ElasticRunning string = "RUNNING"
+
+// ElasticShuttingDown is the state of an elastic instance that is being stopped
+const ElasticShuttingDown string = "SHUTTING_DOWN"
+
+// ElasticTerminated is the state of an elastic instance that has been stopped
+const ElasticTerminated string = "TERMINATED"
+
+// ElasticService handles communication with the elastic agent image configurations and instances
+type ElasticService service
+
+// ElasticImageConfiguration describes the image elastic instances are started from
+type ElasticImageConfiguration struct {
+	ID               int    `json:"id"`
+	Name             string `json:"configurationName"`
+	Description      string `json:"description,omitempty"`
+	ImageID          string `json:"amiId"`
+	InstanceType     string `json:"instanceType"`
+	AvailabilityZone string `json:"availabilityZone,omitempty"`
+	Disabled         bool   `json:"disabled"`
+}
+
+// ElasticInstance is an elastic agent instance. StartedTime is converted from the millisecond
+// epoch value the API returns.
+type ElasticInstance struct {
+	InstanceID        string    `json:"instanceId"`
+	ConfigurationID   int       `json:"configurationId"`
+	ConfigurationName string    `json:"configurationName"`
+	InstanceType      string    `json:"instanceType"`
+	State             string    `json:"state"`
+	AgentID           int       `json:"agentId,omitempty"`
+	StartedTime       time.Time `json:"startedTime"`
+}
+
+// UnmarshalJSON decodes an elastic instance, converting its epoch timestamp to time.Time
+func (i *ElasticInstance) UnmarshalJSON(data []byte) error {
+	type alias ElasticInstance
+	aux := struct {
+		*alias
+		StartedTime epochTime `json:"startedTime"`
+	}{alias: (*alias)(i)}
+
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	i.StartedTime = aux.StartedTime.Time
+	return nil
+}
+
+// Uptime returns how long the instance has been up so far. Instances that haven't started yet, or have
+// been terminated, have no uptime as the API doesn't report when an instance stopped.
+func (i *ElasticInstance) Uptime() time.Duration {
+	if i.StartedTime.IsZero() || i.State == ElasticPending || i.State == ElasticTerminated {
+		return 0
+	}
+	return time.Since(i.StartedTime)
+}
+
+// ElasticUptime adds up the uptime of the instances still running by the id of their image configuration.
+// Terminated instances aren't counted, so it isn't a measure of what the instances have cost.
+func ElasticUptime(instances []*ElasticInstance) map[int]time.Duration {
+	uptime := map[int]time.Duration{}
+	for _, instance := range instances {
+		uptime[instance.ConfigurationID] += instance.Uptime()
+	}
+	return uptime
+}
+
+type startElasticInstancesRequest struct {
+	ConfigurationID int `json:"configurationId"`
+	Quantity        int `json:"quantity"`
+}
+
+// ImageConfigurations returns the elastic image configurations of the server
+func (e *ElasticService) ImageConfigurations() ([]*ElasticImageConfiguration, *http.Response, error) {
+	request, err := e.client.NewRequest(http.MethodGet, "elasticConfiguration", nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	configurations := []*ElasticImageConfiguration{}
+	response, err := e.client.Do(request, &configurations)
+	if err != nil {
+		return nil, response, err
+	}
+
+	if response.StatusCode == 401 {
+		return nil, response, &simpleError{"You must be an admin to access this information"}
+	} else if response.StatusCode != 200 {
+		return nil, response, &simpleError{fmt.Sprintf("Listing elastic image configurations returned %s", response.Status)}
+	}
+
+	return configurations, response, nil
+}
+
+// Instances returns the elastic instances known to the server, including ones that are starting up or stopping
+func (e *ElasticService) Instances() ([]*ElasticInstance, *http.Response, error) {
+	request, err := e.client.NewRequest(http.MethodGet, "elasticInstances", nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	instances := []*ElasticInstance{}
+	response, err := e.client.Do(request, &instances)
+	if err != nil {
+		return nil, response, err
+	}
+
+	if response.StatusCode == 401 {
+		return nil, response, &simpleError{"You must be an admin to access this information"}
+	} else if response.StatusCode != 200 {
+		return nil, response, &simpleError{fmt.Sprintf("Listing elastic instances returned %s", response.Status)}
+	}
+
+	return instances, response, nil
+}
+
+// StartInstances starts quantity new instances of the given image configuration and returns them
+func (e *ElasticService) StartInstances(configurationID, quantity int) ([]*ElasticInstance, *http.Response, error) {
+	if quantity < 1 {
+		return nil, nil, &simpleError{"At least one instance must be started"}
+	}
+
+	body := startElasticInstancesRequest{ConfigurationID: configurationID, Quantity: quantity}
+	request, err := e.client.NewRequest(http.MethodPost, "elasticInstances", body)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	instances := []*ElasticInstance{}
+	response, err := e.client.Do(request, &instances)
+	if err != nil {
+		return nil, response, err
+	}
+
+	switch response.StatusCode {
+	case 200, 201:
+		return instances, response, nil
+	case 401:
+		return nil, response, &simpleError{"You must be an admin to preform this action"}
+	case 404:
+		return nil, response, &simpleError{fmt.Sprintf("Elastic image configuration %d doesn't exist", configurationID)}
+	}
+	return nil, response, &simpleError{fmt.Sprintf("Server responded with unexpected return code %d", response.StatusCode)}
+}
+
+// StopInstance shuts down the elastic instance. A job running on its agent is stopped along with it.
+func (e *ElasticService) StopInstance(instanceID string) (*http.Response, error) {
+	request, err := e.client.NewRequest(http.MethodDelete, fmt.Sprintf("elasticInstances/%s", url.PathEscape(instanceID)), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := e.client.Do(request, nil)
+	if err != nil {
+		return response, err
+	}
+
+	switch response.StatusCode {
+	case 200, 204:
+		return response, nil
+	case 401:
+		return response, &simpleError{"You must be an admin to preform this action"}
+	case 404:
+		return response, &simpleError{fmt.Sprintf("Elastic instance %s doesn't exist", instanceID)}
+	}
+	return response, &simpleError{fmt.Sprintf("Server responded with unexpected return code %d", response.StatusCode)}
+}
@@ -0,0 +1,97 @@
+package bamboo_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	bamboo "github.com/rcarmstrong/go-bamboo"
+)
+
+func TestElasticInstances(t *testing.T) {
+	started := time.Now().Add(-2 * time.Hour)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch strings.TrimPrefix(r.URL.Path, "/rest/api/latest/") {
+		case "elasticConfiguration":
+			fmt.Fprint(w, `[{"id":1,"configurationName":"Linux","amiId":"ami-123","instanceType":"m5.large"}]`)
+		case "elasticInstances":
+			fmt.Fprintf(w, `[
+				{"instanceId":"i-1","configurationId":1,"state":"RUNNING","agentId":7,"startedTime":%d},
+				{"instanceId":"i-2","configurationId":1,"state":"RUNNING","startedTime":%d},
+				{"instanceId":"i-3","configurationId":1,"state":"PENDING"}
+			]`, started.UnixNano()/int64(time.Millisecond), started.UnixNano()/int64(time.Millisecond))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	client := bamboo.NewSimpleClient(nil, "", "")
+	client.SetURL(ts.URL)
+
+	configurations, _, err := client.Elastic.ImageConfigurations()
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(configurations))
+	assert.Equal(t, "ami-123", configurations[0].ImageID)
+
+	instances, _, err := client.Elastic.Instances()
+	assert.NoError(t, err)
+	assert.Equal(t, 3, len(instances))
+	assert.Equal(t, 7, instances[0].AgentID)
+	assert.InDelta(t, (2 * time.Hour).Seconds(), instances[0].Uptime().Seconds(), 5)
+	assert.Equal(t, time.Duration(0), instances[2].Uptime())
+	assert.InDelta(t, (4 * time.Hour).Seconds(), bamboo.ElasticUptime(instances)[1].Seconds(), 10)
+}
+
+func TestStartStopElasticInstances(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path := strings.TrimPrefix(r.URL.Path, "/rest/api/latest/")
+		switch {
+		case path == "elasticInstances" && r.Method == http.MethodPost:
+			body := map[string]int{}
+			json.NewDecoder(r.Body).Decode(&body)
+			if body["configurationId"] != 1 {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			for i := 0; i < body["quantity"]; i++ {
+				if i == 0 {
+					fmt.Fprint(w, "[")
+				} else {
+					fmt.Fprint(w, ",")
+				}
+				fmt.Fprintf(w, `{"instanceId":"i-%d","configurationId":1,"state":"PENDING"}`, i)
+			}
+			fmt.Fprint(w, "]")
+		case path == "elasticInstances/i-1" && r.Method == http.MethodDelete:
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	client := bamboo.NewSimpleClient(nil, "", "")
+	client.SetURL(ts.URL)
+
+	instances, _, err := client.Elastic.StartInstances(1, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(instances))
+	assert.Equal(t, bamboo.ElasticPending, instances[1].State)
+
+	_, _, err = client.Elastic.StartInstances(2, 1)
+	assert.Error(t, err)
+	_, _, err = client.Elastic.StartInstances(1, 0)
+	assert.Error(t, err)
+
+	_, err = client.Elastic.StopInstance("i-1")
+	assert.NoError(t, err)
+	_, err = client.Elastic.StopInstance("i-9")
+	assert.Error(t, err)
+}
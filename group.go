@@ -18,7 +18,7 @@ type groupProjectPlanResponse struct {
 
 // GroupPermissionsList returns a list of group permissions for the given resource. Leave Key blank when setting permissions globally.
 func (p *Permissions) GroupPermissionsList(opts PermissionsOpts) ([]Group, *http.Response, error) {
-	if !knownResources[opts.Resource] {
+	if !opts.resourceType().Valid() {
 		return nil, nil, &simpleError{fmt.Sprintf("Unknown resource %s", opts.Resource)}
 	}
 
@@ -44,7 +44,7 @@ func (p *Permissions) GroupPermissionsList(opts PermissionsOpts) ([]Group, *http
 
 // GroupPermissions returns the group's permissions for the given resource. Leave Key blank when setting permissions globally.
 func (p *Permissions) GroupPermissions(group string, opts PermissionsOpts) ([]string, *http.Response, error) {
-	if !knownResources[opts.Resource] {
+	if !opts.resourceType().Valid() {
 		return nil, nil, &simpleError{fmt.Sprintf("Unknown resource %s", opts.Resource)}
 	}
 
@@ -75,8 +75,14 @@ func (p *Permissions) GroupPermissions(group string, opts PermissionsOpts) ([]st
 
 // SetGroupPermissions sets the group's permissions for the given resource. Leave Key blank when setting permissions globally.
 func (p *Permissions) SetGroupPermissions(group string, permissions []string, opts PermissionsOpts) (*http.Response, error) {
-	if !knownResources[opts.Resource] {
-		return nil, &simpleError{fmt.Sprintf("Unknown resource %s", opts.Resource)}
+	return p.GrantGroupPermissions(group, toPermissions(permissions), opts)
+}
+
+// GrantGroupPermissions is SetGroupPermissions for typed permissions. The permissions are checked with
+// ValidatePermissions before any request is made.
+func (p *Permissions) GrantGroupPermissions(group string, permissions []Permission, opts PermissionsOpts) (*http.Response, error) {
+	if err := ValidatePermissions(opts.resourceType(), permissions); err != nil {
+		return nil, err
 	}
 
 	request, err := p.client.NewRequest(http.MethodPut, editGroupPermissionsURL(opts.Resource, opts.Key, group), permissions)
@@ -106,7 +112,13 @@ func (p *Permissions) SetGroupPermissions(group string, permissions []string, op
 
 // RemoveGroupPermissions removes the given permissions from the group's permissions for the given project's plans. Leave Key blank when setting permissions globally.
 func (p *Permissions) RemoveGroupPermissions(group string, permissions []string, opts PermissionsOpts) (*http.Response, error) {
-	if !knownResources[opts.Resource] {
+	return p.RevokeGroupPermissions(group, toPermissions(permissions), opts)
+}
+
+// RevokeGroupPermissions is RemoveGroupPermissions for typed permissions. Unlike granting, only the
+// resource is checked, not the permissions, so anything the server reports can be revoked.
+func (p *Permissions) RevokeGroupPermissions(group string, permissions []Permission, opts PermissionsOpts) (*http.Response, error) {
+	if !opts.resourceType().Valid() {
 		return nil, &simpleError{fmt.Sprintf("Unknown resource %s", opts.Resource)}
 	}
 
@@ -137,7 +149,7 @@ func (p *Permissions) RemoveGroupPermissions(group string, permissions []string,
 
 // AvailableGroupsPermissionsList returns a list of groups which weren't explicitly granted any permissions to the resource. Leave Key blank when setting permissions globally.
 func (p *Permissions) AvailableGroupsPermissionsList(opts PermissionsOpts) ([]Group, *http.Response, error) {
-	if !knownResources[opts.Resource] {
+	if !opts.resourceType().Valid() {
 		return nil, nil, &simpleError{fmt.Sprintf("Unknown resource %s", opts.Resource)}
 	}
 
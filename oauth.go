@@ -0,0 +1,254 @@
+package bamboo
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha1"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// OAuth endpoints are relative to the REST API base URL as they are served outside of it
+const (
+	oauthRequestTokenPath = "../../../plugins/servlet/oauth/request-token"
+	oauthAuthorizePath    = "../../../plugins/servlet/oauth/authorize"
+	oauthAccessTokenPath  = "../../../plugins/servlet/oauth/access-token"
+)
+
+// OAuthOutOfBand is the callback used when none is set. Bamboo then shows the verifier to the user
+// instead of redirecting to the consumer.
+const OAuthOutOfBand = "oob"
+
+// OAuthService handles the OAuth 1.0a token exchange with Bamboo. The consumer must first be set up
+// as an incoming application link with its RSA public key.
+// https://developer.atlassian.com/server/jira/platform/oauth/
+type OAuthService service
+
+// OAuthConsumer is an application link consumer which signs requests with RSA-SHA1
+// - ConsumerKey: Consumer key of the application link
+// - PrivateKey:  Key matching the public key given to the application link
+// - CallbackURL: Where Bamboo redirects the user after authorizing a request token. Defaults to OAuthOutOfBand
+type OAuthConsumer struct {
+	ConsumerKey string
+	PrivateKey  *rsa.PrivateKey
+	CallbackURL string
+}
+
+// OAuthToken is a request or access token. RSA-SHA1 signatures don't use the secret but it is kept
+// for completeness.
+type OAuthToken struct {
+	Token  string
+	Secret string
+}
+
+// ParseOAuthPrivateKey reads a PEM encoded PKCS #1 or PKCS #8 RSA private key
+func ParseOAuthPrivateKey(data []byte) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, &simpleError{"No PEM encoded private key found"}
+	}
+
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	rsaKey, ok := key.(*rsa.PrivateKey)
+	if !ok {
+		return nil, &simpleError{"OAuth private key must be an RSA key"}
+	}
+	return rsaKey, nil
+}
+
+// Credentials returns the Authorizer signing requests with the given access token
+func (c *OAuthConsumer) Credentials(accessToken *OAuthToken) *OAuthCredentials {
+	return &OAuthCredentials{Consumer: c, Token: accessToken.Token}
+}
+
+// OAuthCredentials sign every request with the access token Token on behalf of Consumer
+type OAuthCredentials struct {
+	Consumer *OAuthConsumer
+	Token    string
+}
+
+// NewOAuthClient returns a new Bamboo API client which signs its requests with consumer and accessToken.
+// If a nil httpClient is provided, http.DefaultClient will be used.
+func NewOAuthClient(httpClient *http.Client, consumer *OAuthConsumer, accessToken *OAuthToken) *Client {
+	return NewClient(httpClient, consumer.Credentials(accessToken))
+}
+
+// Authorization returns an empty string as an OAuth signature covers the request it is sent with.
+// The client signs requests with AuthorizeRequest instead.
+func (o *OAuthCredentials) Authorization() string {
+	return ""
+}
+
+// AuthorizeRequest signs req with the access token. The client calls it from Do so the signature
+// covers the request as it is sent.
+func (o *OAuthCredentials) AuthorizeRequest(req *http.Request) error {
+	return o.Consumer.sign(req, map[string]string{"oauth_token": o.Token})
+}
+
+func (o *OAuthCredentials) signsRequest() {}
+
+// sign adds an RSA-SHA1 signed OAuth Authorization header to req. extra holds the oauth parameters
+// specific to the request, such as oauth_token.
+func (c *OAuthConsumer) sign(req *http.Request, extra map[string]string) error {
+	if c.ConsumerKey == "" || c.PrivateKey == nil {
+		return &simpleError{"An OAuth consumer needs a consumer key and private key"}
+	}
+
+	nonce := make([]byte, 16)
+	if _, err := rand.Read(nonce); err != nil {
+		return err
+	}
+
+	oauthParams := map[string]string{
+		"oauth_consumer_key":     c.ConsumerKey,
+		"oauth_nonce":            hex.EncodeToString(nonce),
+		"oauth_signature_method": "RSA-SHA1",
+		"oauth_timestamp":        strconv.FormatInt(time.Now().Unix(), 10),
+		"oauth_version":          "1.0",
+	}
+	for key, value := range extra {
+		oauthParams[key] = value
+	}
+
+	hashed := sha1.Sum([]byte(oauthBaseString(req, oauthParams)))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, c.PrivateKey, crypto.SHA1, hashed[:])
+	if err != nil {
+		return err
+	}
+	oauthParams["oauth_signature"] = base64.StdEncoding.EncodeToString(signature)
+
+	keys := make([]string, 0, len(oauthParams))
+	for key := range oauthParams {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	header := make([]string, len(keys))
+	for i, key := range keys {
+		header[i] = fmt.Sprintf(`%s="%s"`, oauthEscape(key), oauthEscape(oauthParams[key]))
+	}
+	req.Header.Set("Authorization", "OAuth "+strings.Join(header, ", "))
+	return nil
+}
+
+// oauthBaseString builds the signature base string of RFC 5849 section 3.4.1 from the method, URL,
+// query and oauth parameters. Request bodies are JSON so never contribute parameters.
+func oauthBaseString(req *http.Request, oauthParams map[string]string) string {
+	var params []string
+	for key, values := range req.URL.Query() {
+		for _, value := range values {
+			params = append(params, oauthEscape(key)+"="+oauthEscape(value))
+		}
+	}
+	for key, value := range oauthParams {
+		params = append(params, oauthEscape(key)+"="+oauthEscape(value))
+	}
+	sort.Strings(params)
+
+	scheme, host := strings.ToLower(req.URL.Scheme), strings.ToLower(req.URL.Host)
+	if (scheme == "http" && strings.HasSuffix(host, ":80")) || (scheme == "https" && strings.HasSuffix(host, ":443")) {
+		host = host[:strings.LastIndex(host, ":")]
+	}
+	baseURL := scheme + "://" + host + req.URL.EscapedPath()
+
+	return strings.ToUpper(req.Method) + "&" + oauthEscape(baseURL) + "&" + oauthEscape(strings.Join(params, "&"))
+}
+
+// oauthEscape percent encodes everything but the unreserved characters of RFC 3986
+func oauthEscape(s string) string {
+	var buf bytes.Buffer
+	for _, b := range []byte(s) {
+		if ('A' <= b && b <= 'Z') || ('a' <= b && b <= 'z') || ('0' <= b && b <= '9') || b == '-' || b == '.' || b == '_' || b == '~' {
+			buf.WriteByte(b)
+		} else {
+			fmt.Fprintf(&buf, "%%%02X", b)
+		}
+	}
+	return buf.String()
+}
+
+// RequestToken obtains a request token for consumer, the first step of the OAuth dance.
+// Send the user to AuthorizationURL with it next.
+func (o *OAuthService) RequestToken(consumer *OAuthConsumer) (*OAuthToken, *http.Response, error) {
+	callback := consumer.CallbackURL
+	if callback == "" {
+		callback = OAuthOutOfBand
+	}
+	return o.exchangeToken(consumer, oauthRequestTokenPath, map[string]string{"oauth_callback": callback})
+}
+
+// AuthorizationURL returns the page where the user grants requestToken access to their account.
+// Bamboo passes the verifier needed by AccessToken to the callback, or shows it to the user for
+// an out of band callback.
+func (o *OAuthService) AuthorizationURL(requestToken *OAuthToken) (string, error) {
+	u, err := o.client.BaseURL.Parse(oauthAuthorizePath)
+	if err != nil {
+		return "", err
+	}
+	u.RawQuery = url.Values{"oauth_token": {requestToken.Token}}.Encode()
+	return u.String(), nil
+}
+
+// AccessToken exchanges an authorized request token and its verifier for an access token, the last
+// step of the OAuth dance. Pass the access token to NewOAuthClient or OAuthConsumer.Credentials.
+func (o *OAuthService) AccessToken(consumer *OAuthConsumer, requestToken *OAuthToken, verifier string) (*OAuthToken, *http.Response, error) {
+	return o.exchangeToken(consumer, oauthAccessTokenPath, map[string]string{
+		"oauth_token":    requestToken.Token,
+		"oauth_verifier": verifier,
+	})
+}
+
+// exchangeToken posts a request signed by consumer to one of the token endpoints, which answer with
+// a form encoded token rather than JSON
+func (o *OAuthService) exchangeToken(consumer *OAuthConsumer, path string, params map[string]string) (*OAuthToken, *http.Response, error) {
+	u, err := o.client.BaseURL.Parse(path)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	request, err := http.NewRequest(http.MethodPost, u.String(), nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := consumer.sign(request, params); err != nil {
+		return nil, nil, err
+	}
+
+	body := &bytes.Buffer{}
+	response, err := o.client.Do(request, body)
+	if err != nil {
+		return nil, response, err
+	}
+
+	values, _ := url.ParseQuery(body.String())
+	if response.StatusCode != 200 {
+		problem := values.Get("oauth_problem")
+		if problem == "" {
+			problem = response.Status
+		}
+		return nil, response, &simpleError{fmt.Sprintf("OAuth token request was rejected: %s", problem)}
+	}
+
+	token := &OAuthToken{Token: values.Get("oauth_token"), Secret: values.Get("oauth_token_secret")}
+	if token.Token == "" {
+		return nil, response, &simpleError{"Server responded without an OAuth token"}
+	}
+	return token, response, nil
+}
@@ -0,0 +1,167 @@
+package bamboo_test
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha1"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	bamboo "github.com/rcarmstrong/go-bamboo"
+)
+
+// oauthEscape is url.QueryEscape with spaces encoded as RFC 5849 requires
+func oauthEscape(s string) string {
+	return strings.Replace(url.QueryEscape(s), "+", "%20", -1)
+}
+
+// verifyOAuth checks the RSA-SHA1 signature of r and returns its oauth parameters, or nil if it doesn't verify
+func verifyOAuth(r *http.Request, key *rsa.PublicKey, host string) map[string]string {
+	header := r.Header.Get("Authorization")
+	if !strings.HasPrefix(header, "OAuth ") {
+		return nil
+	}
+
+	oauthParams := map[string]string{}
+	for _, field := range strings.Split(strings.TrimPrefix(header, "OAuth "), ", ") {
+		parts := strings.SplitN(field, "=", 2)
+		value, _ := url.QueryUnescape(strings.Trim(parts[1], `"`))
+		oauthParams[parts[0]] = value
+	}
+
+	var params []string
+	for k, values := range r.URL.Query() {
+		for _, v := range values {
+			params = append(params, oauthEscape(k)+"="+oauthEscape(v))
+		}
+	}
+	for k, v := range oauthParams {
+		if k != "oauth_signature" {
+			params = append(params, oauthEscape(k)+"="+oauthEscape(v))
+		}
+	}
+	sort.Strings(params)
+
+	base := r.Method + "&" + oauthEscape("http://"+host+r.URL.Path) + "&" + oauthEscape(strings.Join(params, "&"))
+	signature, _ := base64.StdEncoding.DecodeString(oauthParams["oauth_signature"])
+	hashed := sha1.Sum([]byte(base))
+	if rsa.VerifyPKCS1v15(key, crypto.SHA1, hashed[:], signature) != nil {
+		return nil
+	}
+	return oauthParams
+}
+
+func TestOAuth(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+
+	var host string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		params := verifyOAuth(r, &key.PublicKey, host)
+		if params == nil || params["oauth_consumer_key"] != "go-bamboo" || params["oauth_signature_method"] != "RSA-SHA1" {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte("oauth_problem=signature_invalid"))
+			return
+		}
+
+		switch r.URL.Path {
+		case "/plugins/servlet/oauth/request-token":
+			assert.Equal(t, "oob", params["oauth_callback"])
+			w.Write([]byte("oauth_token=request&oauth_token_secret=request-secret"))
+		case "/plugins/servlet/oauth/access-token":
+			if params["oauth_token"] != "request" || params["oauth_verifier"] != "1234" {
+				w.WriteHeader(http.StatusUnauthorized)
+				w.Write([]byte("oauth_problem=token_rejected"))
+				return
+			}
+			w.Write([]byte("oauth_token=access&oauth_token_secret=access-secret"))
+		case "/rest/api/latest/currentUser":
+			assert.Equal(t, "access", params["oauth_token"])
+			w.Write([]byte(`{"name":"integration"}`))
+		case "/rest/api/latest/deploy/environment/7/results":
+			assert.Equal(t, "25", r.URL.Query().Get("max-result"))
+			w.Write([]byte(`{"size":1,"start-index":25,"max-result":25,"results":[{"id":3,"deploymentState":"SUCCESS"}]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+	host = strings.TrimPrefix(ts.URL, "http://")
+
+	consumer := &bamboo.OAuthConsumer{ConsumerKey: "go-bamboo", PrivateKey: key}
+	client := bamboo.NewClient(nil, nil)
+	client.SetURL(ts.URL)
+
+	requestToken, _, err := client.OAuth.RequestToken(consumer)
+	assert.NoError(t, err)
+	assert.Equal(t, &bamboo.OAuthToken{Token: "request", Secret: "request-secret"}, requestToken)
+
+	authorizeURL, err := client.OAuth.AuthorizationURL(requestToken)
+	assert.NoError(t, err)
+	assert.Equal(t, ts.URL+"/plugins/servlet/oauth/authorize?oauth_token=request", authorizeURL)
+
+	_, _, err = client.OAuth.AccessToken(consumer, requestToken, "wrong")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "token_rejected")
+
+	accessToken, _, err := client.OAuth.AccessToken(consumer, requestToken, "1234")
+	assert.NoError(t, err)
+	assert.Equal(t, "access", accessToken.Token)
+
+	oauthClient := bamboo.NewOAuthClient(nil, consumer, accessToken)
+	oauthClient.SetURL(ts.URL)
+	request, err := oauthClient.NewRequest(http.MethodGet, "currentUser", nil)
+	assert.NoError(t, err)
+	user := &bamboo.CurrentUser{}
+	_, err = oauthClient.Do(request, user)
+	assert.NoError(t, err)
+	assert.Equal(t, "integration", user.Name)
+
+	// Query parameters are part of the signature
+	request, err = oauthClient.NewRequest(http.MethodGet, "currentUser?expand=a%20b", nil)
+	assert.NoError(t, err)
+	response, err := oauthClient.Do(request, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+
+	// Pages are requested by adding to the query after NewRequest, which the signature still covers
+	history, err := oauthClient.Deploys.DeployEnvironmentHistory(7, &bamboo.Pagination{Start: 25, Limit: 25})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(history.Results))
+
+	// A different key fails to verify
+	other, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+	_, _, err = client.OAuth.RequestToken(&bamboo.OAuthConsumer{ConsumerKey: "go-bamboo", PrivateKey: other})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "signature_invalid")
+}
+
+func TestParseOAuthPrivateKey(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+
+	pkcs1 := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+	parsed, err := bamboo.ParseOAuthPrivateKey(pkcs1)
+	assert.NoError(t, err)
+	assert.True(t, key.Equal(parsed))
+
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	assert.NoError(t, err)
+	parsed, err = bamboo.ParseOAuthPrivateKey(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}))
+	assert.NoError(t, err)
+	assert.True(t, key.Equal(parsed))
+
+	_, err = bamboo.ParseOAuthPrivateKey([]byte("not a key"))
+	assert.Error(t, err)
+}
@@ -1,60 +1,156 @@
 package bamboo
 
+import (
+	"fmt"
+)
+
+// Permission is the string the API expects for a single permission on a resource.
+// The permission constants are untyped so they can be used as a string or a Permission.
+type Permission string
+
+// ResourceType is the URL piece identifying the kind of resource permissions are granted on.
+// The resource constants are untyped so they can be used as a string or a ResourceType.
+type ResourceType string
+
 // WritePermission the sting the API expects for write permissions.
 // Allows a user to view and edit the configuration of the plan and its jobs, not including permissions or stages.
-const WritePermission string = "WRITE"
+const WritePermission = "WRITE"
 
 // BuildPermission the sting the API expects for build permissions.
 // Allows a user to trigger a manual build, or suspend and resume the plan.
-const BuildPermission string = "BUILD"
+const BuildPermission = "BUILD"
+
+// DeployPermission is the string the API expects for deploy permissions on an environment.
+// The API reuses the build permission for this.
+const DeployPermission = BuildPermission
 
 // ReadPermission the sting the API expects for read permissions.
 // Allows a user to view the plan and its builds.
-const ReadPermission string = "READ"
+const ReadPermission = "READ"
+
+// ViewConfigurationPermission is the string the API expects for view configuration permissions.
+// Allows a user to view the configuration of a plan, deployment project or environment without being able to edit it.
+const ViewConfigurationPermission = "VIEWCONFIGURATION"
 
 // ClonePermission the sting the API expects for clone permissions.
 // Allows a user to clone the plan.
-const ClonePermission string = "CLONE"
+const ClonePermission = "CLONE"
 
 // AdminPermission is the sting the API expects for admin permissions.
 // Allows a user to edit all aspects of the plan including permissions and stages.
-const AdminPermission string = "ADMINISTRATION"
+const AdminPermission = "ADMINISTRATION"
+
+// RestrictedAdminPermission is the string the API expects for global restricted admin permissions
+const RestrictedAdminPermission = "RESTRICTEDADMINISTRATION"
 
 // CreatePermission is the string the API expects when allowing a user/group to create a resource
-const CreatePermission string = "CREATE"
+const CreatePermission = "CREATE"
 
 // CreateRepositoryPermission is the string the API expects when allowing a user/group to create a repository
-const CreateRepositoryPermission string = "CREATEREPOSITORY"
+const CreateRepositoryPermission = "CREATEREPOSITORY"
 
 // PlanResource is the URL piece when getting plan permissions
-const PlanResource string = "plan"
+const PlanResource = "plan"
 
 // GlobalResource is the URL piece when getting global permissions
-const GlobalResource string = "global"
+const GlobalResource = "global"
 
 // RepositoryResource is the URL piece when getting repository permissions
-const RepositoryResource string = "repository"
+const RepositoryResource = "repository"
 
 // ProjectResource is the URL piece when getting project permissions
-const ProjectResource string = "project"
+const ProjectResource = "project"
 
 // EnvironmentResource is the URL piece when getting environment permissions
-const EnvironmentResource string = "environment"
+const EnvironmentResource = "environment"
 
 // ProjectPlanResource is the URL piece when getting projectplan permissions
-const ProjectPlanResource string = "projectplan"
+const ProjectPlanResource = "projectplan"
 
 // DeploymentResource is the URL piece when getting deployment permissions
-const DeploymentResource string = "deployment"
-
-var knownResources = map[string]bool{
-	PlanResource:        true,
-	GlobalResource:      true,
-	RepositoryResource:  true,
-	ProjectResource:     true,
-	EnvironmentResource: true,
-	ProjectPlanResource: true,
-	DeploymentResource:  true,
+const DeploymentResource = "deployment"
+
+// resourcePermissions lists the permissions that can be granted on each kind of resource
+var resourcePermissions = map[ResourceType][]Permission{
+	GlobalResource:      {ReadPermission, CreatePermission, CreateRepositoryPermission, RestrictedAdminPermission, AdminPermission},
+	ProjectResource:     {ReadPermission, CreatePermission, CreateRepositoryPermission, AdminPermission},
+	ProjectPlanResource: {ReadPermission, ViewConfigurationPermission, WritePermission, BuildPermission, ClonePermission, AdminPermission},
+	PlanResource:        {ReadPermission, ViewConfigurationPermission, WritePermission, BuildPermission, ClonePermission, AdminPermission},
+	DeploymentResource:  {ReadPermission, ViewConfigurationPermission, WritePermission, AdminPermission},
+	EnvironmentResource: {ReadPermission, ViewConfigurationPermission, WritePermission, DeployPermission},
+	RepositoryResource:  {ReadPermission, AdminPermission},
+}
+
+// Valid reports whether the resource type is one the API knows about
+func (r ResourceType) Valid() bool {
+	_, ok := resourcePermissions[r]
+	return ok
+}
+
+// Permissions returns the permissions that can be granted on the resource type
+func (r ResourceType) Permissions() []Permission {
+	return append([]Permission(nil), resourcePermissions[r]...)
+}
+
+// Allows reports whether the given permission can be granted on the resource type
+func (r ResourceType) Allows(permission Permission) bool {
+	for _, p := range resourcePermissions[r] {
+		if p == permission {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidatePermissions checks that every permission can be granted on the given resource type
+func ValidatePermissions(resource ResourceType, permissions []Permission) error {
+	if !resource.Valid() {
+		return &simpleError{fmt.Sprintf("Unknown resource %s", resource)}
+	}
+
+	for _, permission := range permissions {
+		if !resource.Allows(permission) {
+			return &simpleError{fmt.Sprintf("Permission %s cannot be granted on resource %s", permission, resource)}
+		}
+	}
+	return nil
+}
+
+// ValidateRolePermissions checks that every permission can be granted to the given role on the resource type.
+// The anonymous role can only ever be granted read permission.
+func ValidateRolePermissions(role string, resource ResourceType, permissions []Permission) error {
+	if role == AnonymousRole {
+		for _, permission := range permissions {
+			if permission != ReadPermission {
+				return &simpleError{fmt.Sprintf("The anonymous role can only be granted %s, not %s", ReadPermission, permission)}
+			}
+		}
+	}
+	return ValidatePermissions(resource, permissions)
+}
+
+// toPermissions converts the permission strings the API methods accept to Permissions
+func toPermissions(permissions []string) []Permission {
+	if permissions == nil {
+		return nil
+	}
+	converted := make([]Permission, len(permissions))
+	for i, permission := range permissions {
+		converted[i] = Permission(permission)
+	}
+	return converted
+}
+
+// permissionStrings converts Permissions to the strings the API methods accept
+func permissionStrings(permissions []Permission) []string {
+	if permissions == nil {
+		return nil
+	}
+	converted := make([]string, len(permissions))
+	for i, permission := range permissions {
+		converted[i] = string(permission)
+	}
+	return converted
 }
 
 // Permissions is the container for all permissions related endpoints
@@ -67,3 +163,7 @@ type PermissionsOpts struct {
 	Resource string
 	Key      string
 }
+
+func (o PermissionsOpts) resourceType() ResourceType {
+	return ResourceType(o.Resource)
+}
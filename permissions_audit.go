@@ -0,0 +1,194 @@
+package bamboo
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// PermissionResource is a resource permissions can be granted on, along with its display name
+type PermissionResource struct {
+	PermissionsOpts
+	Name string
+}
+
+// AuditOptions specifies the optional parameters for the Audit method
+// - Concurrency:   Maximum number of concurrent requests
+// - IncludeGlobal: Include global permissions in the report
+type AuditOptions struct {
+	Concurrency   int
+	IncludeGlobal bool
+}
+
+// AuditEntry is the permissions a single principal holds on a single resource
+type AuditEntry struct {
+	Resource      ResourceType `json:"resource"`
+	Key           string       `json:"key,omitempty"`
+	Name          string       `json:"name,omitempty"`
+	PrincipalType string       `json:"principalType"`
+	Principal     string       `json:"principal"`
+	Permissions   []Permission `json:"permissions"`
+}
+
+// ResourceID returns the resource and key of the entry joined with a slash, e.g. "plan/CORE-TEST"
+func (e *AuditEntry) ResourceID() string {
+	if e.Key == "" {
+		return string(e.Resource)
+	}
+	return string(e.Resource) + "/" + e.Key
+}
+
+// PrincipalID returns the principal type and name of the entry joined with a colon, e.g. "user:alice"
+func (e *AuditEntry) PrincipalID() string {
+	return e.PrincipalType + ":" + e.Principal
+}
+
+// AuditReport is every permission granted across a Bamboo server
+type AuditReport struct {
+	Entries []*AuditEntry
+}
+
+// ByPrincipal indexes the report entries by principal id (see AuditEntry.PrincipalID)
+func (r *AuditReport) ByPrincipal() map[string][]*AuditEntry {
+	index := map[string][]*AuditEntry{}
+	for _, e := range r.Entries {
+		index[e.PrincipalID()] = append(index[e.PrincipalID()], e)
+	}
+	return index
+}
+
+// ByResource indexes the report entries by resource id (see AuditEntry.ResourceID)
+func (r *AuditReport) ByResource() map[string][]*AuditEntry {
+	index := map[string][]*AuditEntry{}
+	for _, e := range r.Entries {
+		index[e.ResourceID()] = append(index[e.ResourceID()], e)
+	}
+	return index
+}
+
+// WriteJSON writes the report to w as a JSON object with "byPrincipal" and "byResource" indexes
+func (r *AuditReport) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(struct {
+		ByPrincipal map[string][]*AuditEntry `json:"byPrincipal"`
+		ByResource  map[string][]*AuditEntry `json:"byResource"`
+	}{r.ByPrincipal(), r.ByResource()})
+}
+
+// WriteCSV writes the report to w as CSV with one row per entry. Permissions are separated by semicolons.
+func (r *AuditReport) WriteCSV(w io.Writer) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write([]string{"resource", "key", "name", "principal_type", "principal", "permissions"}); err != nil {
+		return err
+	}
+
+	for _, e := range r.Entries {
+		permissions := make([]string, len(e.Permissions))
+		for i, permission := range e.Permissions {
+			permissions[i] = string(permission)
+		}
+
+		row := []string{string(e.Resource), e.Key, e.Name, e.PrincipalType, e.Principal, strings.Join(permissions, ";")}
+		if err := writer.Write(row); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+// ListPermissionResources returns every project, project plan, plan, deployment project and
+// environment on the server as resources that permissions can be read from.
+func (p *Permissions) ListPermissionResources() ([]*PermissionResource, error) {
+	resources := []*PermissionResource{}
+
+	projects, _, err := p.client.Projects.ListProjects()
+	if err != nil {
+		return nil, err
+	}
+	for _, project := range projects {
+		resources = append(resources,
+			&PermissionResource{PermissionsOpts{Resource: ProjectResource, Key: project.Key}, project.Name},
+			&PermissionResource{PermissionsOpts{Resource: ProjectPlanResource, Key: project.Key}, project.Name},
+		)
+	}
+
+	plans, _, err := p.client.Plans.ListPlans()
+	if err != nil {
+		return nil, err
+	}
+	for _, plan := range plans {
+		resources = append(resources, &PermissionResource{PermissionsOpts{Resource: PlanResource, Key: plan.Key}, plan.Name})
+	}
+
+	deploys, err := p.client.Deploys.ListDeploys()
+	if err != nil {
+		return nil, err
+	}
+	for _, deploy := range deploys {
+		resources = append(resources, &PermissionResource{PermissionsOpts{Resource: DeploymentResource, Key: strconv.Itoa(deploy.ID)}, deploy.Name})
+		for _, environment := range deploy.Environments {
+			name := deploy.Name + " - " + environment.Name
+			resources = append(resources, &PermissionResource{PermissionsOpts{Resource: EnvironmentResource, Key: strconv.Itoa(environment.ID)}, name})
+		}
+	}
+
+	return resources, nil
+}
+
+// Audit collects the user, group and role permissions of every project, plan, deployment project and environment
+func (p *Permissions) Audit(opts *AuditOptions) (*AuditReport, error) {
+	if opts == nil {
+		opts = &AuditOptions{}
+	}
+
+	resources, err := p.ListPermissionResources()
+	if err != nil {
+		return nil, err
+	}
+	if opts.IncludeGlobal {
+		resources = append([]*PermissionResource{{PermissionsOpts{Resource: GlobalResource}, "Global"}}, resources...)
+	}
+
+	collected := make([][]*AuditEntry, len(resources))
+	err = forEach(len(resources), opts.Concurrency, func(i int) error {
+		resource := resources[i]
+		current, err := p.ResourcePermissions(resource.PermissionsOpts)
+		if err != nil {
+			return err
+		}
+
+		for _, principalType := range []string{UserPrincipal, GroupPrincipal, RolePrincipal} {
+			principals := current.principals(principalType)
+			for _, name := range sortedKeys(principals) {
+				collected[i] = append(collected[i], &AuditEntry{
+					Resource:      resource.resourceType(),
+					Key:           resource.Key,
+					Name:          resource.Name,
+					PrincipalType: principalType,
+					Principal:     name,
+					Permissions:   principals[name],
+				})
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	report := &AuditReport{}
+	for _, entries := range collected {
+		report.Entries = append(report.Entries, entries...)
+	}
+	sort.SliceStable(report.Entries, func(i, j int) bool {
+		return report.Entries[i].ResourceID() < report.Entries[j].ResourceID()
+	})
+
+	return report, nil
+}
@@ -0,0 +1,74 @@
+package bamboo_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	bamboo "github.com/rcarmstrong/go-bamboo"
+)
+
+func TestAudit(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(auditStub))
+	defer ts.Close()
+
+	client := bamboo.NewSimpleClient(nil, "", "")
+	client.SetURL(ts.URL)
+
+	report, err := client.Permissions.Audit(&bamboo.AuditOptions{Concurrency: 3})
+	assert.NoError(t, err)
+	assert.Equal(t, 3, len(report.Entries))
+
+	byPrincipal := report.ByPrincipal()
+	assert.Equal(t, 2, len(byPrincipal["user:alice"]))
+	assert.Equal(t, "environment/20", byPrincipal["user:alice"][0].ResourceID())
+	assert.Equal(t, "Core - prod", byPrincipal["user:alice"][0].Name)
+	assert.Equal(t, 1, len(byPrincipal["role:LOGGED_IN"]))
+
+	byResource := report.ByResource()
+	assert.Equal(t, 2, len(byResource["plan/CORE-TEST"]))
+
+	csvOut := &bytes.Buffer{}
+	assert.NoError(t, report.WriteCSV(csvOut))
+	assert.Equal(t, "resource,key,name,principal_type,principal,permissions\n"+
+		"environment,20,Core - prod,user,alice,READ;BUILD\n"+
+		"plan,CORE-TEST,Test,user,alice,READ\n"+
+		"plan,CORE-TEST,Test,role,LOGGED_IN,READ\n", csvOut.String())
+
+	jsonOut := &bytes.Buffer{}
+	assert.NoError(t, report.WriteJSON(jsonOut))
+	decoded := map[string]map[string][]*bamboo.AuditEntry{}
+	assert.NoError(t, json.Unmarshal(jsonOut.Bytes(), &decoded))
+	assert.Equal(t, 2, len(decoded["byPrincipal"]["user:alice"]))
+}
+
+func auditStub(w http.ResponseWriter, r *http.Request) {
+	path := strings.TrimPrefix(r.URL.Path, "/rest/api/latest/")
+
+	switch path {
+	case "project.json":
+		fmt.Fprint(w, `{"projects":{"project":[{"key":"CORE","name":"Core"}]}}`)
+	case "plan.json":
+		fmt.Fprint(w, `{"plans":{"size":1,"plan":[{"key":"CORE-TEST","name":"Test"}]}}`)
+	case "deploy/project/all":
+		fmt.Fprint(w, `[{"id":3,"name":"Core","environments":[{"id":20,"name":"prod"}]}]`)
+	case "permissions/environment/20/users":
+		fmt.Fprint(w, `{"results":[{"name":"alice","permissions":["READ","BUILD"]}]}`)
+	case "permissions/plan/CORE-TEST/users":
+		fmt.Fprint(w, `{"results":[{"name":"alice","permissions":["READ"]},{"name":"bob","permissions":[]}]}`)
+	case "permissions/plan/CORE-TEST/roles":
+		fmt.Fprint(w, `{"results":[{"name":"LOGGED_IN","permissions":["READ"]}]}`)
+	default:
+		if strings.HasPrefix(path, "permissions/") {
+			fmt.Fprint(w, `{"results":[]}`)
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
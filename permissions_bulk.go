@@ -0,0 +1,231 @@
+package bamboo
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// ResourceSelector picks the resources a bulk permission change applies to
+type ResourceSelector func(resource *PermissionResource) bool
+
+// AllResources selects every project, project plan, plan, deployment project and environment
+func AllResources() ResourceSelector {
+	return func(resource *PermissionResource) bool {
+		return true
+	}
+}
+
+// PlansInProject selects every plan in the given project
+func PlansInProject(projectKey string) ResourceSelector {
+	return func(resource *PermissionResource) bool {
+		return resource.Resource == PlanResource && strings.HasPrefix(resource.Key, projectKey+"-")
+	}
+}
+
+// EnvironmentsNamed selects the environments whose name matches the given pattern, in which * matches
+// any run of characters and ? any single character. Environment names are prefixed by their deployment
+// project, e.g. "* - Production" matches the Production environment of every deployment project.
+func EnvironmentsNamed(pattern string) ResourceSelector {
+	matcher := globPattern(pattern)
+	return func(resource *PermissionResource) bool {
+		return resource.Resource == EnvironmentResource && matcher.MatchString(resource.Name)
+	}
+}
+
+// globPattern compiles a pattern using * and ? wildcards. Unlike path.Match, / has no special meaning
+// as deployment project and environment names may contain it.
+func globPattern(pattern string) *regexp.Regexp {
+	quoted := regexp.QuoteMeta(pattern)
+	quoted = strings.Replace(quoted, `\*`, ".*", -1)
+	quoted = strings.Replace(quoted, `\?`, ".", -1)
+	return regexp.MustCompile("^" + quoted + "$")
+}
+
+// BulkOptions specifies the optional parameters for the BulkGrant and BulkRevoke methods
+// - Concurrency:       Maximum number of concurrent requests
+// - RequestsPerSecond: Maximum rate requests are made at. Unlimited if zero
+// - DryRun:            Work out what would change on each resource without changing anything
+type BulkOptions struct {
+	Concurrency       int
+	RequestsPerSecond float64
+	DryRun            bool
+}
+
+// BulkResult is the outcome of a bulk permission change on a single resource
+// - Permissions: The permissions granted or revoked. Those the resource doesn't support are left out
+// - Skipped:     None of the requested permissions apply to the resource so nothing was sent
+// - Err:         Why the change failed, nil if it succeeded
+type BulkResult struct {
+	Resource    *PermissionResource
+	Permissions []Permission
+	Skipped     bool
+	Err         error
+}
+
+func (r *BulkResult) String() string {
+	status := "ok"
+	switch {
+	case r.Err != nil:
+		status = "failed: " + r.Err.Error()
+	case r.Skipped:
+		status = "skipped"
+	}
+
+	resource := string(r.Resource.Resource)
+	if r.Resource.Key != "" {
+		resource += " " + r.Resource.Key
+	}
+	return fmt.Sprintf("%s %v: %s", resource, r.Permissions, status)
+}
+
+// BulkSummary holds the result of a bulk permission change for every selected resource
+type BulkSummary struct {
+	Results []*BulkResult
+}
+
+// Failed returns the results of the resources the change failed on
+func (s *BulkSummary) Failed() []*BulkResult {
+	failed := []*BulkResult{}
+	for _, r := range s.Results {
+		if r.Err != nil {
+			failed = append(failed, r)
+		}
+	}
+	return failed
+}
+
+// BulkGrant grants the permissions to the principal on every resource picked by selector.
+// principalType is one of UserPrincipal, GroupPrincipal or RolePrincipal. Failures on individual
+// resources are reported in the summary; an error is only returned if the resources can't be listed.
+func (p *Permissions) BulkGrant(principalType, principal string, permissions []Permission, selector ResourceSelector, opts *BulkOptions) (*BulkSummary, error) {
+	return p.bulkChange(principalType, principal, permissions, true, selector, opts)
+}
+
+// BulkRevoke revokes the permissions from the principal on every resource picked by selector.
+// Passing nil permissions revokes everything the principal holds on each resource, which is
+// useful when offboarding a user. The principal's grants are read first so resources where it
+// holds nothing are skipped. See BulkGrant for how failures are reported.
+func (p *Permissions) BulkRevoke(principalType, principal string, permissions []Permission, selector ResourceSelector, opts *BulkOptions) (*BulkSummary, error) {
+	return p.bulkChange(principalType, principal, permissions, false, selector, opts)
+}
+
+func (p *Permissions) bulkChange(principalType, principal string, permissions []Permission, grant bool, selector ResourceSelector, opts *BulkOptions) (*BulkSummary, error) {
+	if opts == nil {
+		opts = &BulkOptions{}
+	}
+	if selector == nil {
+		selector = AllResources()
+	}
+
+	resources, err := p.ListPermissionResources()
+	if err != nil {
+		return nil, err
+	}
+
+	summary := &BulkSummary{}
+	for _, resource := range resources {
+		if selector(resource) {
+			summary.Results = append(summary.Results, &BulkResult{Resource: resource})
+		}
+	}
+
+	limiter := newRateLimiter(opts.RequestsPerSecond)
+	defer limiter.stop()
+
+	forEach(len(summary.Results), opts.Concurrency, func(i int) error {
+		result := summary.Results[i]
+		wanted := permissions
+		if !grant && wanted == nil {
+			limiter.wait()
+			held, err := p.grantedPermissions(principalType, principal, result.Resource.PermissionsOpts)
+			if err != nil {
+				result.Err = err
+				return nil
+			}
+			// Revoke only what is held, an empty list rather than nil as nil means every permission
+			wanted = append([]Permission{}, held...)
+		}
+
+		result.Permissions = applicablePermissions(principalType, principal, wanted, result.Resource.resourceType())
+		if len(result.Permissions) == 0 {
+			result.Skipped = true
+			return nil
+		}
+		if opts.DryRun {
+			return nil
+		}
+
+		change := &PermissionChange{Opts: result.Resource.PermissionsOpts, PrincipalType: principalType, Principal: principal}
+		if grant {
+			change.Grant = result.Permissions
+		} else {
+			change.Revoke = result.Permissions
+		}
+
+		limiter.wait()
+		result.Err = p.applyPermissionChange(change)
+		return nil
+	})
+
+	return summary, nil
+}
+
+// grantedPermissions returns the permissions granted directly to the principal on the resource
+func (p *Permissions) grantedPermissions(principalType, principal string, opts PermissionsOpts) ([]Permission, error) {
+	switch principalType {
+	case UserPrincipal:
+		users, _, err := p.UserPermissionsList(opts)
+		if err != nil {
+			return nil, err
+		}
+		for _, u := range users {
+			if u.Name == principal {
+				return toPermissions(u.Permissions), nil
+			}
+		}
+	case GroupPrincipal:
+		groups, _, err := p.GroupPermissionsList(opts)
+		if err != nil {
+			return nil, err
+		}
+		for _, g := range groups {
+			if g.Name == principal {
+				return toPermissions(g.Permissions), nil
+			}
+		}
+	case RolePrincipal:
+		roles, _, err := p.RolePermissionsList(opts)
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range roles {
+			if r.Name == principal {
+				return toPermissions(r.Permissions), nil
+			}
+		}
+	default:
+		return nil, &simpleError{fmt.Sprintf("Unknown principal type %s", principalType)}
+	}
+	return nil, nil
+}
+
+// applicablePermissions returns the permissions that can be granted to the principal on the resource type.
+// nil permissions stands for every permission the resource type supports.
+func applicablePermissions(principalType, principal string, permissions []Permission, resource ResourceType) []Permission {
+	if permissions == nil {
+		permissions = resource.Permissions()
+	}
+
+	applicable := []Permission{}
+	for _, permission := range permissions {
+		if !resource.Allows(permission) {
+			continue
+		}
+		if principalType == RolePrincipal && principal == AnonymousRole && permission != ReadPermission {
+			continue
+		}
+		applicable = append(applicable, permission)
+	}
+	return applicable
+}
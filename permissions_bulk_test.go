@@ -0,0 +1,129 @@
+package bamboo_test
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	bamboo "github.com/rcarmstrong/go-bamboo"
+)
+
+// bulkStub lists a handful of resources and records every permission change made to them
+type bulkStub struct {
+	mu    sync.Mutex
+	calls []string
+}
+
+func (b *bulkStub) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	path := strings.TrimPrefix(r.URL.Path, "/rest/api/latest/")
+
+	if r.Method != http.MethodGet {
+		if path == "permissions/plan/WEB-SITE/users/alice" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		body, _ := ioutil.ReadAll(r.Body)
+		b.mu.Lock()
+		b.calls = append(b.calls, fmt.Sprintf("%s %s %s", r.Method, strings.TrimPrefix(path, "permissions/"), strings.TrimSpace(string(body))))
+		b.mu.Unlock()
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	switch path {
+	case "permissions/plan/CORE-TEST/users":
+		fmt.Fprint(w, `{"results":[{"name":"alice","permissions":["READ","BUILD"]},{"name":"alicia","permissions":["WRITE"]}]}`)
+	case "permissions/plan/WEB-SITE/users":
+		fmt.Fprint(w, `{"results":[{"name":"alice","permissions":["READ"]}]}`)
+	case "project.json":
+		fmt.Fprint(w, `{"projects":{"project":[{"key":"CORE","name":"Core"}]}}`)
+	case "plan.json":
+		fmt.Fprint(w, `{"plans":{"size":3,"plan":[{"key":"CORE-TEST","name":"Test"},{"key":"CORE-OTHER","name":"Other"},{"key":"WEB-SITE","name":"Site"}]}}`)
+	case "deploy/project/all":
+		fmt.Fprint(w, `[{"id":3,"name":"Core","environments":[{"id":20,"name":"Production"},{"id":21,"name":"Staging"}]},
+			{"id":4,"name":"Web/API","environments":[{"id":30,"name":"Production"}]}]`)
+	default:
+		if strings.HasPrefix(path, "permissions/") {
+			fmt.Fprint(w, `{"results":[]}`)
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func (b *bulkStub) sortedCalls() []string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	calls := append([]string{}, b.calls...)
+	sort.Strings(calls)
+	return calls
+}
+
+func TestBulkGrant(t *testing.T) {
+	stub := &bulkStub{}
+	ts := httptest.NewServer(stub)
+	defer ts.Close()
+
+	client := bamboo.NewSimpleClient(nil, "", "")
+	client.SetURL(ts.URL)
+
+	permissions := []bamboo.Permission{bamboo.ReadPermission, bamboo.BuildPermission}
+	summary, err := client.Permissions.BulkGrant(bamboo.GroupPrincipal, "developers", permissions, bamboo.PlansInProject("CORE"), &bamboo.BulkOptions{Concurrency: 2, RequestsPerSecond: 100})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(summary.Results))
+	assert.Empty(t, summary.Failed())
+	assert.Equal(t, []string{
+		`PUT plan/CORE-OTHER/groups/developers ["READ","BUILD"]`,
+		`PUT plan/CORE-TEST/groups/developers ["READ","BUILD"]`,
+	}, stub.sortedCalls())
+
+	// Projects don't support BUILD so only READ is granted there
+	summary, err = client.Permissions.BulkGrant(bamboo.GroupPrincipal, "developers", permissions, bamboo.AllResources(), &bamboo.BulkOptions{DryRun: true})
+	assert.NoError(t, err)
+	assert.Equal(t, 10, len(summary.Results))
+	assert.Equal(t, "project CORE [READ]: ok", summary.Results[0].String())
+	assert.Equal(t, "deployment 3 [READ]: ok", summary.Results[5].String())
+	assert.Equal(t, 2, len(stub.sortedCalls()))
+}
+
+func TestBulkRevoke(t *testing.T) {
+	stub := &bulkStub{}
+	ts := httptest.NewServer(stub)
+	defer ts.Close()
+
+	client := bamboo.NewSimpleClient(nil, "", "")
+	client.SetURL(ts.URL)
+
+	// * matches deployment project names containing a /
+	summary, err := client.Permissions.BulkRevoke(bamboo.UserPrincipal, "alice", []bamboo.Permission{bamboo.BuildPermission}, bamboo.EnvironmentsNamed("* - Production"), nil)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(summary.Results))
+	assert.Equal(t, []string{
+		`DELETE environment/20/users/alice ["BUILD"]`,
+		`DELETE environment/30/users/alice ["BUILD"]`,
+	}, stub.sortedCalls())
+
+	// Revoking nil permissions revokes only what alice holds, skipping resources where she has nothing.
+	// WEB-SITE fails on the server.
+	stub.calls = nil
+	summary, err = client.Permissions.BulkRevoke(bamboo.UserPrincipal, "alice", nil, nil, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, 10, len(summary.Results))
+
+	failed := summary.Failed()
+	assert.Equal(t, 1, len(failed))
+	assert.Equal(t, "WEB-SITE", failed[0].Resource.Key)
+	assert.Equal(t, []string{`DELETE plan/CORE-TEST/users/alice ["READ","BUILD"]`}, stub.sortedCalls())
+
+	// The anonymous role can only ever hold READ
+	summary, err = client.Permissions.BulkGrant(bamboo.RolePrincipal, bamboo.AnonymousRole, []bamboo.Permission{bamboo.ReadPermission, bamboo.WritePermission}, bamboo.PlansInProject("WEB"), &bamboo.BulkOptions{DryRun: true})
+	assert.NoError(t, err)
+	assert.Equal(t, []bamboo.Permission{bamboo.ReadPermission}, summary.Results[0].Permissions)
+}
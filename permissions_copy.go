@@ -0,0 +1,137 @@
+package bamboo
+
+import (
+	"fmt"
+)
+
+// permissionFallbacks maps a permission to the closest one to use when the target resource doesn't support it
+var permissionFallbacks = map[Permission]Permission{
+	RestrictedAdminPermission:   AdminPermission,
+	AdminPermission:             WritePermission,
+	ViewConfigurationPermission: ReadPermission,
+}
+
+// PermissionMapping explicitly maps permissions granted on the source of a copy to the permission to grant
+// on the target, taking precedence over MapPermission. E.g. {BuildPermission: DeployPermission} lets
+// everyone who can build a plan deploy to the environment it is copied to.
+type PermissionMapping map[Permission]Permission
+
+// UnmappedPermission is a permission granted on the source of a copy that was skipped on the target
+type UnmappedPermission struct {
+	PrincipalType string     `json:"principalType"`
+	Principal     string     `json:"principal"`
+	Permission    Permission `json:"permission"`
+	Reason        string     `json:"reason"`
+}
+
+func (u *UnmappedPermission) String() string {
+	return fmt.Sprintf("%s %s: %s (%s)", u.PrincipalType, u.Principal, u.Permission, u.Reason)
+}
+
+// PermissionCopy is the result of copying permissions from one resource to another
+// - Changes:  The changes planned, and applied unless it was a dry run, on the target
+// - Unmapped: Source permissions that could not be granted on the target
+type PermissionCopy struct {
+	Changes  []*PermissionChange
+	Unmapped []*UnmappedPermission
+}
+
+// MapPermission returns the permission to grant on the target resource type in place of one granted on
+// the source resource type. Permissions the target doesn't support fall back to the closest weaker
+// permission, e.g. ADMINISTRATION on a plan becomes WRITE on an environment. The bool is false when
+// there is no equivalent. BUILD is never mapped onto an environment from another kind of resource:
+// the API spells DEPLOY the same way, so it would let everyone who can build deploy as well.
+func MapPermission(permission Permission, from, to ResourceType) (Permission, bool) {
+	if permission == BuildPermission && to == EnvironmentResource && from != EnvironmentResource {
+		return "", false
+	}
+
+	for {
+		if to.Allows(permission) {
+			return permission, true
+		}
+
+		fallback, ok := permissionFallbacks[permission]
+		if !ok {
+			return "", false
+		}
+		permission = fallback
+	}
+}
+
+// MapResourcePermissions translates every grant of source into the equivalent grants on target, using
+// mapping before MapPermission. Permissions with no equivalent on the target are returned separately.
+func MapResourcePermissions(source *ResourcePermissions, target PermissionsOpts, mapping PermissionMapping) (*ResourcePermissions, []*UnmappedPermission) {
+	mapped := &ResourcePermissions{
+		Resource: target.resourceType(),
+		Key:      target.Key,
+		Users:    map[string][]Permission{},
+		Groups:   map[string][]Permission{},
+		Roles:    map[string][]Permission{},
+	}
+	unmapped := []*UnmappedPermission{}
+
+	for _, principalType := range []string{UserPrincipal, GroupPrincipal, RolePrincipal} {
+		granted := source.principals(principalType)
+		for _, name := range sortedKeys(granted) {
+			seen := map[Permission]bool{}
+			permissions := []Permission{}
+
+			for _, permission := range granted[name] {
+				to, ok := mapping[permission]
+				if !ok {
+					to, ok = MapPermission(permission, source.Resource, target.resourceType())
+				}
+
+				reason := ""
+				switch {
+				case !ok && permission == BuildPermission && target.Resource == EnvironmentResource:
+					reason = "build doesn't grant deploy unless mapped explicitly"
+				case !ok || !target.resourceType().Allows(to):
+					reason = fmt.Sprintf("no equivalent on %s", target.Resource)
+				case principalType == RolePrincipal && ValidateRolePermissions(name, target.resourceType(), []Permission{to}) != nil:
+					reason = fmt.Sprintf("can't be granted to %s", name)
+				}
+				if reason != "" {
+					unmapped = append(unmapped, &UnmappedPermission{PrincipalType: principalType, Principal: name, Permission: permission, Reason: reason})
+					continue
+				}
+
+				if !seen[to] {
+					seen[to] = true
+					permissions = append(permissions, to)
+				}
+			}
+
+			if len(permissions) > 0 {
+				mapped.principals(principalType)[name] = permissions
+			}
+		}
+	}
+
+	return mapped, unmapped
+}
+
+// CopyPermissions grants every user, group and role permission of the source resource on the target resource,
+// which may be of a different type. This is useful after cloning a plan or creating a deployment environment
+// as permissions are not carried over. With opts.Prune the target's permissions are made to match the source
+// exactly, otherwise existing target permissions are left in place. opts.DryRun and opts.Out behave as they do
+// for SyncPermissions. mapping may be nil, see MapResourcePermissions.
+func (p *Permissions) CopyPermissions(source, target PermissionsOpts, mapping PermissionMapping, opts *SyncOptions) (*PermissionCopy, error) {
+	if !source.resourceType().Valid() {
+		return nil, &simpleError{fmt.Sprintf("Unknown resource %s", source.Resource)}
+	}
+	if !target.resourceType().Valid() {
+		return nil, &simpleError{fmt.Sprintf("Unknown resource %s", target.Resource)}
+	}
+
+	granted, err := p.ResourcePermissions(source)
+	if err != nil {
+		return nil, err
+	}
+
+	desired, unmapped := MapResourcePermissions(granted, target, mapping)
+	changes, err := p.SyncPermissions(&PermissionsDocument{Resources: []*ResourcePermissions{desired}}, opts)
+
+	return &PermissionCopy{Changes: changes, Unmapped: unmapped}, err
+}
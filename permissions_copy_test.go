@@ -0,0 +1,100 @@
+package bamboo_test
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	bamboo "github.com/rcarmstrong/go-bamboo"
+)
+
+func TestMapPermission(t *testing.T) {
+	mapped, ok := bamboo.MapPermission(bamboo.AdminPermission, bamboo.PlanResource, bamboo.EnvironmentResource)
+	assert.True(t, ok)
+	assert.Equal(t, bamboo.Permission(bamboo.WritePermission), mapped)
+
+	mapped, ok = bamboo.MapPermission(bamboo.ViewConfigurationPermission, bamboo.PlanResource, bamboo.ProjectResource)
+	assert.True(t, ok)
+	assert.Equal(t, bamboo.Permission(bamboo.ReadPermission), mapped)
+
+	// Building a plan doesn't turn into deploying to an environment
+	_, ok = bamboo.MapPermission(bamboo.BuildPermission, bamboo.PlanResource, bamboo.EnvironmentResource)
+	assert.False(t, ok)
+
+	mapped, ok = bamboo.MapPermission(bamboo.DeployPermission, bamboo.EnvironmentResource, bamboo.EnvironmentResource)
+	assert.True(t, ok)
+	assert.Equal(t, bamboo.Permission(bamboo.DeployPermission), mapped)
+
+	_, ok = bamboo.MapPermission(bamboo.ClonePermission, bamboo.PlanResource, bamboo.DeploymentResource)
+	assert.False(t, ok)
+}
+
+func TestCopyPermissions(t *testing.T) {
+	calls := []string{}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path := strings.TrimPrefix(r.URL.Path, "/rest/api/latest/permissions/")
+
+		if r.Method != http.MethodGet {
+			body, _ := ioutil.ReadAll(r.Body)
+			calls = append(calls, fmt.Sprintf("%s %s %s", r.Method, path, strings.TrimSpace(string(body))))
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		switch path {
+		case "plan/CORE-TEST/users":
+			fmt.Fprint(w, `{"results":[{"name":"alice","permissions":["READ","WRITE","CLONE","ADMINISTRATION"]}]}`)
+		case "plan/CORE-TEST/groups":
+			fmt.Fprint(w, `{"results":[{"name":"developers","permissions":["READ","BUILD"]}]}`)
+		case "plan/CORE-TEST/roles":
+			fmt.Fprint(w, `{"results":[{"name":"LOGGED_IN","permissions":["READ","CLONE"]},{"name":"ANONYMOUS","permissions":["READ"]}]}`)
+		case "environment/7/users":
+			fmt.Fprint(w, `{"results":[{"name":"bob","permissions":["READ"]}]}`)
+		default:
+			fmt.Fprint(w, `{"results":[]}`)
+		}
+	}))
+	defer ts.Close()
+
+	client := bamboo.NewSimpleClient(nil, "", "")
+	client.SetURL(ts.URL)
+
+	source := bamboo.PermissionsOpts{Resource: bamboo.PlanResource, Key: "CORE-TEST"}
+	target := bamboo.PermissionsOpts{Resource: bamboo.EnvironmentResource, Key: "7"}
+
+	result, err := client.Permissions.CopyPermissions(source, target, nil, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, 4, len(result.Changes))
+	assert.Equal(t, []string{
+		`PUT environment/7/users/alice ["READ","WRITE"]`,
+		`PUT environment/7/groups/developers ["READ"]`,
+		`PUT environment/7/roles/ANONYMOUS ["READ"]`,
+		`PUT environment/7/roles/LOGGED_IN ["READ"]`,
+	}, calls)
+
+	assert.Equal(t, 3, len(result.Unmapped))
+	assert.Equal(t, "user alice: CLONE (no equivalent on environment)", result.Unmapped[0].String())
+	assert.Equal(t, "group developers: BUILD (build doesn't grant deploy unless mapped explicitly)", result.Unmapped[1].String())
+	assert.Equal(t, "role LOGGED_IN: CLONE (no equivalent on environment)", result.Unmapped[2].String())
+
+	// Deploy has to be asked for
+	calls = []string{}
+	mapping := bamboo.PermissionMapping{bamboo.BuildPermission: bamboo.DeployPermission}
+	result, err = client.Permissions.CopyPermissions(source, target, mapping, nil)
+	assert.NoError(t, err)
+	assert.Contains(t, calls, `PUT environment/7/groups/developers ["BUILD","READ"]`)
+	assert.Equal(t, 2, len(result.Unmapped))
+
+	// Pruning also revokes what bob holds on the target as he has nothing on the source
+	calls = []string{}
+	result, err = client.Permissions.CopyPermissions(source, target, nil, &bamboo.SyncOptions{DryRun: true, Prune: true})
+	assert.NoError(t, err)
+	assert.Equal(t, 5, len(result.Changes))
+	assert.Equal(t, "environment 7: user bob: revoke [READ]", result.Changes[1].String())
+	assert.Empty(t, calls)
+}
@@ -0,0 +1,121 @@
+package bamboo
+
+import (
+	"fmt"
+	"strings"
+)
+
+// PermissionSource records where an effective permission came from
+// - Resource, Key:            The resource the permission was granted on
+// - PrincipalType, Principal: Who it was granted to. The user, one of their groups or a role
+type PermissionSource struct {
+	Resource      ResourceType `json:"resource"`
+	Key           string       `json:"key,omitempty"`
+	PrincipalType string       `json:"principalType"`
+	Principal     string       `json:"principal"`
+}
+
+func (s *PermissionSource) String() string {
+	resource := string(s.Resource)
+	if s.Key != "" {
+		resource += " " + s.Key
+	}
+	return fmt.Sprintf("%s %s on %s", s.PrincipalType, s.Principal, resource)
+}
+
+// EffectivePermissions is the full set of permissions a user holds on a resource.
+// Permissions maps each permission to every source that grants it.
+type EffectivePermissions struct {
+	Username    string                             `json:"username"`
+	Resource    ResourceType                       `json:"resource"`
+	Key         string                             `json:"key,omitempty"`
+	Permissions map[Permission][]*PermissionSource `json:"permissions"`
+}
+
+// Has reports whether the user holds the given permission
+func (e *EffectivePermissions) Has(permission Permission) bool {
+	return len(e.Permissions[permission]) > 0
+}
+
+// List returns the sorted permissions the user holds
+func (e *EffectivePermissions) List() []Permission {
+	permissions := make([]Permission, 0, len(e.Permissions))
+	for permission := range e.Permissions {
+		permissions = append(permissions, permission)
+	}
+	sortPermissions(permissions)
+	return permissions
+}
+
+// String formats the effective permissions one permission per line along with their sources
+func (e *EffectivePermissions) String() string {
+	lines := []string{}
+	for _, permission := range e.List() {
+		sources := []string{}
+		for _, s := range e.Permissions[permission] {
+			sources = append(sources, s.String())
+		}
+		lines = append(lines, string(permission)+": "+strings.Join(sources, ", "))
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (e *EffectivePermissions) add(permissions []Permission, source *PermissionSource) {
+	for _, permission := range permissions {
+		e.Permissions[permission] = append(e.Permissions[permission], source)
+	}
+}
+
+// EffectivePermissions resolves the permissions the given user holds on a resource by combining
+// direct grants, grants to the user's groups, and grants to the LOGGED_IN and ANONYMOUS roles.
+// Permissions on a plan also include those inherited from its project's plan permissions.
+// An empty username resolves the permissions of an anonymous user.
+func (p *Permissions) EffectivePermissions(username string, opts PermissionsOpts) (*EffectivePermissions, error) {
+	if !opts.resourceType().Valid() {
+		return nil, &simpleError{fmt.Sprintf("Unknown resource %s", opts.Resource)}
+	}
+
+	resources := []PermissionsOpts{opts}
+	if opts.Resource == PlanResource {
+		projectKey := strings.SplitN(opts.Key, "-", 2)[0]
+		resources = append(resources, PermissionsOpts{Resource: ProjectPlanResource, Key: projectKey})
+	}
+
+	groups := []string{}
+	if username != "" {
+		var err error
+		groups, err = p.client.UserAdmin.UserGroups(username)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	effective := &EffectivePermissions{
+		Username:    username,
+		Resource:    opts.resourceType(),
+		Key:         opts.Key,
+		Permissions: map[Permission][]*PermissionSource{},
+	}
+
+	for _, resource := range resources {
+		granted, err := p.ResourcePermissions(resource)
+		if err != nil {
+			return nil, err
+		}
+
+		source := func(principalType, principal string) *PermissionSource {
+			return &PermissionSource{Resource: resource.resourceType(), Key: resource.Key, PrincipalType: principalType, Principal: principal}
+		}
+
+		if username != "" {
+			effective.add(granted.Users[username], source(UserPrincipal, username))
+			for _, group := range groups {
+				effective.add(granted.Groups[group], source(GroupPrincipal, group))
+			}
+			effective.add(granted.Roles[LoggedInRole], source(RolePrincipal, LoggedInRole))
+		}
+		effective.add(granted.Roles[AnonymousRole], source(RolePrincipal, AnonymousRole))
+	}
+
+	return effective, nil
+}
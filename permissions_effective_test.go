@@ -0,0 +1,66 @@
+package bamboo_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	bamboo "github.com/rcarmstrong/go-bamboo"
+)
+
+func TestEffectivePermissions(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(effectivePermissionsStub))
+	defer ts.Close()
+
+	client := bamboo.NewSimpleClient(nil, "", "")
+	client.SetURL(ts.URL)
+
+	opts := bamboo.PermissionsOpts{Resource: bamboo.PlanResource, Key: "CORE-TEST"}
+	effective, err := client.Permissions.EffectivePermissions("alice", opts)
+	assert.NoError(t, err)
+	assert.Equal(t, []bamboo.Permission{"BUILD", "CLONE", "READ", "WRITE"}, effective.List())
+
+	assert.Equal(t, 1, len(effective.Permissions["WRITE"]))
+	assert.Equal(t, bamboo.UserPrincipal, effective.Permissions["WRITE"][0].PrincipalType)
+
+	assert.Equal(t, 1, len(effective.Permissions["BUILD"]))
+	assert.Equal(t, "developers", effective.Permissions["BUILD"][0].Principal)
+
+	assert.Equal(t, 1, len(effective.Permissions["CLONE"]))
+	assert.Equal(t, bamboo.ResourceType(bamboo.ProjectPlanResource), effective.Permissions["CLONE"][0].Resource)
+	assert.Equal(t, "CORE", effective.Permissions["CLONE"][0].Key)
+
+	assert.Equal(t, 3, len(effective.Permissions["READ"]))
+
+	anonymous, err := client.Permissions.EffectivePermissions("", opts)
+	assert.NoError(t, err)
+	assert.Equal(t, []bamboo.Permission{"READ"}, anonymous.List())
+	assert.Equal(t, bamboo.AnonymousRole, anonymous.Permissions["READ"][0].Principal)
+}
+
+func effectivePermissionsStub(w http.ResponseWriter, r *http.Request) {
+	path := strings.TrimPrefix(r.URL.Path, "/rest/api/latest/")
+
+	switch path {
+	case "admin/users/alice/groups":
+		fmt.Fprint(w, `{"results":[{"name":"developers"}],"start":0,"limit":100,"size":1}`)
+	case "permissions/plan/CORE-TEST/users":
+		fmt.Fprint(w, `{"results":[{"name":"alice","permissions":["READ","WRITE"]},{"name":"bob","permissions":["ADMINISTRATION"]}]}`)
+	case "permissions/plan/CORE-TEST/groups":
+		fmt.Fprint(w, `{"results":[{"name":"developers","permissions":["BUILD"]},{"name":"admins","permissions":["ADMINISTRATION"]}]}`)
+	case "permissions/plan/CORE-TEST/roles":
+		fmt.Fprint(w, `{"results":[{"name":"ANONYMOUS","permissions":["READ"]}]}`)
+	case "permissions/projectplan/CORE/roles":
+		fmt.Fprint(w, `{"results":[{"name":"LOGGED_IN","permissions":["READ","CLONE"]}]}`)
+	default:
+		if strings.HasPrefix(path, "permissions/") {
+			fmt.Fprint(w, `{"results":[]}`)
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
@@ -0,0 +1,57 @@
+package bamboo
+
+import (
+	"encoding/json"
+	"io"
+	"time"
+)
+
+// PermissionSnapshot is a point in time copy of every permission granted on a set of resources
+type PermissionSnapshot struct {
+	TakenAt   time.Time              `json:"takenAt"`
+	Resources []*ResourcePermissions `json:"resources"`
+}
+
+// Write encodes the snapshot to w as JSON
+func (s *PermissionSnapshot) Write(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(s)
+}
+
+// ReadPermissionSnapshot decodes a snapshot previously written with PermissionSnapshot.Write
+func ReadPermissionSnapshot(r io.Reader) (*PermissionSnapshot, error) {
+	snapshot := &PermissionSnapshot{}
+	if err := json.NewDecoder(r).Decode(snapshot); err != nil {
+		return nil, err
+	}
+	return snapshot, nil
+}
+
+// SnapshotPermissions captures the user, group and role permissions of the given resources
+func (p *Permissions) SnapshotPermissions(resources ...PermissionsOpts) (*PermissionSnapshot, error) {
+	snapshot := &PermissionSnapshot{TakenAt: time.Now()}
+
+	for _, opts := range resources {
+		current, err := p.ResourcePermissions(opts)
+		if err != nil {
+			return nil, err
+		}
+		snapshot.Resources = append(snapshot.Resources, current)
+	}
+
+	return snapshot, nil
+}
+
+// RestorePermissions returns the resources in the snapshot to the permissions they had when it was taken.
+// Only the differences are applied: missing permissions are granted and permissions that were added
+// since the snapshot are revoked. opts.Prune is ignored as restoring always revokes added permissions.
+func (p *Permissions) RestorePermissions(snapshot *PermissionSnapshot, opts *SyncOptions) ([]*PermissionChange, error) {
+	restoreOpts := SyncOptions{}
+	if opts != nil {
+		restoreOpts = *opts
+	}
+	restoreOpts.Prune = true
+
+	return p.SyncPermissions(&PermissionsDocument{Resources: snapshot.Resources}, &restoreOpts)
+}
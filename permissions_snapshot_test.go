@@ -0,0 +1,48 @@
+package bamboo_test
+
+import (
+	"bytes"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	bamboo "github.com/rcarmstrong/go-bamboo"
+)
+
+func TestSnapshotAndRestorePermissions(t *testing.T) {
+	recorder := &permissionsRecorder{}
+	ts := httptest.NewServer(recorder)
+	defer ts.Close()
+
+	client := bamboo.NewSimpleClient(nil, "", "")
+	client.SetURL(ts.URL)
+
+	opts := bamboo.PermissionsOpts{Resource: bamboo.PlanResource, Key: "CORE-TEST"}
+	snapshot, err := client.Permissions.SnapshotPermissions(opts)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(snapshot.Resources))
+	assert.Equal(t, []bamboo.Permission{"READ", "WRITE"}, snapshot.Resources[0].Users["alice"])
+
+	buf := &bytes.Buffer{}
+	assert.NoError(t, snapshot.Write(buf))
+	restored, err := bamboo.ReadPermissionSnapshot(buf)
+	assert.NoError(t, err)
+	assert.Equal(t, snapshot.Resources, restored.Resources)
+
+	// Nothing changed since the snapshot so nothing needs restoring
+	changes, err := client.Permissions.RestorePermissions(restored, nil)
+	assert.NoError(t, err)
+	assert.Empty(t, changes)
+	assert.Empty(t, recorder.calls)
+
+	// Edit the snapshot so bob should also hold BUILD and alice should hold nothing
+	restored.Resources[0].Users["bob"] = []bamboo.Permission{"READ", "BUILD"}
+	delete(restored.Resources[0].Users, "alice")
+	changes, err = client.Permissions.RestorePermissions(restored, &bamboo.SyncOptions{DryRun: true})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(changes))
+	assert.Equal(t, "plan CORE-TEST: user bob: grant [BUILD]", changes[0].String())
+	assert.Equal(t, "plan CORE-TEST: user alice: revoke [READ WRITE]", changes[1].String())
+	assert.Empty(t, recorder.calls)
+}
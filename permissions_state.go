@@ -0,0 +1,295 @@
+package bamboo
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// UserPrincipal is the principal type of permissions granted to a single user
+const UserPrincipal string = "user"
+
+// GroupPrincipal is the principal type of permissions granted to a group of users
+const GroupPrincipal string = "group"
+
+// RolePrincipal is the principal type of permissions granted to a role
+const RolePrincipal string = "role"
+
+// LoggedInRole is the name of the role every authenticated user belongs to
+const LoggedInRole string = "LOGGED_IN"
+
+// AnonymousRole is the name of the role every user, authenticated or not, belongs to
+const AnonymousRole string = "ANONYMOUS"
+
+// ResourcePermissions holds every permission granted on a single resource, keyed by principal name.
+// Leave Key blank for global permissions.
+type ResourcePermissions struct {
+	Resource ResourceType            `json:"resource" yaml:"resource"`
+	Key      string                  `json:"key,omitempty" yaml:"key,omitempty"`
+	Users    map[string][]Permission `json:"users,omitempty" yaml:"users,omitempty"`
+	Groups   map[string][]Permission `json:"groups,omitempty" yaml:"groups,omitempty"`
+	Roles    map[string][]Permission `json:"roles,omitempty" yaml:"roles,omitempty"`
+}
+
+// Opts returns the PermissionsOpts identifying the resource
+func (r *ResourcePermissions) Opts() PermissionsOpts {
+	return PermissionsOpts{Resource: string(r.Resource), Key: r.Key}
+}
+
+// validate checks that every permission can be granted to its principal on the resource
+func (r *ResourcePermissions) validate() error {
+	for _, principalType := range []string{UserPrincipal, GroupPrincipal, RolePrincipal} {
+		for name, permissions := range r.principals(principalType) {
+			var err error
+			if principalType == RolePrincipal {
+				err = ValidateRolePermissions(name, r.Resource, permissions)
+			} else {
+				err = ValidatePermissions(r.Resource, permissions)
+			}
+			if err != nil {
+				return &simpleError{fmt.Sprintf("%s %s on %s %s: %s", principalType, name, r.Resource, r.Key, err)}
+			}
+		}
+	}
+	return nil
+}
+
+func (r *ResourcePermissions) principals(principalType string) map[string][]Permission {
+	switch principalType {
+	case UserPrincipal:
+		return r.Users
+	case GroupPrincipal:
+		return r.Groups
+	case RolePrincipal:
+		return r.Roles
+	}
+	return nil
+}
+
+// PermissionChange is a set of permissions to grant to and revoke from a single principal on a single resource
+type PermissionChange struct {
+	Opts          PermissionsOpts `json:"opts"`
+	PrincipalType string          `json:"principalType"`
+	Principal     string          `json:"principal"`
+	Grant         []Permission    `json:"grant,omitempty"`
+	Revoke        []Permission    `json:"revoke,omitempty"`
+}
+
+func (c *PermissionChange) String() string {
+	resource := string(c.Opts.Resource)
+	if c.Opts.Key != "" {
+		resource += " " + c.Opts.Key
+	}
+
+	actions := []string{}
+	if len(c.Grant) > 0 {
+		actions = append(actions, fmt.Sprintf("grant %v", c.Grant))
+	}
+	if len(c.Revoke) > 0 {
+		actions = append(actions, fmt.Sprintf("revoke %v", c.Revoke))
+	}
+
+	return fmt.Sprintf("%s: %s %s: %s", resource, c.PrincipalType, c.Principal, strings.Join(actions, ", "))
+}
+
+// ResourcePermissions returns every user, group and role permission granted on the given resource
+func (p *Permissions) ResourcePermissions(opts PermissionsOpts) (*ResourcePermissions, error) {
+	users, _, err := p.UserPermissionsList(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	groups, _, err := p.GroupPermissionsList(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	roles, _, err := p.RolePermissionsList(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	current := &ResourcePermissions{
+		Resource: opts.resourceType(),
+		Key:      opts.Key,
+		Users:    map[string][]Permission{},
+		Groups:   map[string][]Permission{},
+		Roles:    map[string][]Permission{},
+	}
+	for _, u := range users {
+		if len(u.Permissions) > 0 {
+			current.Users[u.Name] = toPermissions(u.Permissions)
+		}
+	}
+	for _, g := range groups {
+		if len(g.Permissions) > 0 {
+			current.Groups[g.Name] = toPermissions(g.Permissions)
+		}
+	}
+	for _, r := range roles {
+		if len(r.Permissions) > 0 {
+			current.Roles[r.Name] = toPermissions(r.Permissions)
+		}
+	}
+
+	return current, nil
+}
+
+// DiffPermissions returns the changes needed to bring the actual permissions of a resource to the desired ones.
+// Only principals named in desired are changed unless prune is set, in which case every permission
+// of principals missing from desired is revoked as well.
+func DiffPermissions(desired, actual *ResourcePermissions, prune bool) []*PermissionChange {
+	changes := []*PermissionChange{}
+
+	for _, principalType := range []string{UserPrincipal, GroupPrincipal, RolePrincipal} {
+		want := desired.principals(principalType)
+		have := actual.principals(principalType)
+
+		for _, name := range sortedKeys(want) {
+			grant, revoke := diffPermissionSets(want[name], have[name])
+			if len(grant) > 0 || len(revoke) > 0 {
+				changes = append(changes, &PermissionChange{
+					Opts:          desired.Opts(),
+					PrincipalType: principalType,
+					Principal:     name,
+					Grant:         grant,
+					Revoke:        revoke,
+				})
+			}
+		}
+
+		if !prune {
+			continue
+		}
+
+		for _, name := range sortedKeys(have) {
+			if _, ok := want[name]; ok || len(have[name]) == 0 {
+				continue
+			}
+			_, revoke := diffPermissionSets(nil, have[name])
+			changes = append(changes, &PermissionChange{
+				Opts:          desired.Opts(),
+				PrincipalType: principalType,
+				Principal:     name,
+				Revoke:        revoke,
+			})
+		}
+	}
+
+	return changes
+}
+
+// ApplyPermissionChanges grants and then revokes the permissions of each change in order.
+// It stops at the first change that fails.
+func (p *Permissions) ApplyPermissionChanges(changes []*PermissionChange) error {
+	for _, c := range changes {
+		if err := p.applyPermissionChange(c); err != nil {
+			return &simpleError{fmt.Sprintf("Applying %s failed: %s", c, err)}
+		}
+	}
+	return nil
+}
+
+func (p *Permissions) applyPermissionChange(c *PermissionChange) error {
+	var err error
+	switch c.PrincipalType {
+	case UserPrincipal:
+		if len(c.Grant) > 0 {
+			if _, err = p.GrantUserPermissions(c.Principal, c.Grant, c.Opts); err != nil {
+				return err
+			}
+		}
+		if len(c.Revoke) > 0 {
+			_, err = p.RevokeUserPermissions(c.Principal, c.Revoke, c.Opts)
+		}
+	case GroupPrincipal:
+		if len(c.Grant) > 0 {
+			if _, err = p.GrantGroupPermissions(c.Principal, c.Grant, c.Opts); err != nil {
+				return err
+			}
+		}
+		if len(c.Revoke) > 0 {
+			_, err = p.RevokeGroupPermissions(c.Principal, c.Revoke, c.Opts)
+		}
+	case RolePrincipal:
+		err = p.applyRolePermissionChange(c)
+	default:
+		err = &simpleError{fmt.Sprintf("Unknown principal type %s", c.PrincipalType)}
+	}
+	return err
+}
+
+func (p *Permissions) applyRolePermissionChange(c *PermissionChange) error {
+	var err error
+	switch c.Principal {
+	case LoggedInRole:
+		if len(c.Grant) > 0 {
+			if _, err = p.GrantLoggedInUsersPermissions(c.Grant, c.Opts); err != nil {
+				return err
+			}
+		}
+		if len(c.Revoke) > 0 {
+			_, err = p.RevokeLoggedInUsersPermissions(c.Revoke, c.Opts)
+		}
+	case AnonymousRole:
+		if err = ValidateRolePermissions(AnonymousRole, c.Opts.resourceType(), c.Grant); err != nil {
+			return err
+		}
+		if err = ValidateRolePermissions(AnonymousRole, c.Opts.resourceType(), c.Revoke); err != nil {
+			return err
+		}
+		if len(c.Grant) > 0 {
+			if _, err = p.SetAnonymousReadPermission(c.Opts); err != nil {
+				return err
+			}
+		}
+		if len(c.Revoke) > 0 {
+			_, err = p.RemoveAnonymousReadPermission(c.Opts)
+		}
+	default:
+		err = &simpleError{fmt.Sprintf("Unknown role %s", c.Principal)}
+	}
+	return err
+}
+
+// diffPermissionSets returns the sorted permissions in want but not have and in have but not want
+func diffPermissionSets(want, have []Permission) (grant, revoke []Permission) {
+	wanted := map[Permission]bool{}
+	for _, permission := range want {
+		wanted[permission] = true
+	}
+
+	had := map[Permission]bool{}
+	for _, permission := range have {
+		if had[permission] {
+			continue
+		}
+		had[permission] = true
+		if !wanted[permission] {
+			revoke = append(revoke, permission)
+		}
+	}
+
+	for permission := range wanted {
+		if !had[permission] {
+			grant = append(grant, permission)
+		}
+	}
+
+	sortPermissions(grant)
+	sortPermissions(revoke)
+	return grant, revoke
+}
+
+func sortPermissions(permissions []Permission) {
+	sort.Slice(permissions, func(i, j int) bool { return permissions[i] < permissions[j] })
+}
+
+func sortedKeys(m map[string][]Permission) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
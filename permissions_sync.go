@@ -0,0 +1,92 @@
+package bamboo
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+
+	"gopkg.in/yaml.v3"
+)
+
+// PermissionsDocument describes the desired permissions of a set of resources.
+// For example:
+//
+//	resources:
+//	  - resource: plan
+//	    key: CORE-TEST
+//	    users:
+//	      alice: [READ, BUILD]
+//	    groups:
+//	      developers: [READ, WRITE, BUILD]
+//	    roles:
+//	      LOGGED_IN: [READ]
+type PermissionsDocument struct {
+	Resources []*ResourcePermissions `json:"resources" yaml:"resources"`
+}
+
+// SyncOptions specifies the optional parameters for the SyncPermissions method
+// - DryRun: Compute and print the changes without applying them
+// - Prune:  Revoke the permissions of principals not named in the document
+// - Out:    Where the planned changes are printed. Nothing is printed if nil
+type SyncOptions struct {
+	DryRun bool
+	Prune  bool
+	Out    io.Writer
+}
+
+// ParsePermissionsDocument reads a YAML or JSON permissions document
+func ParsePermissionsDocument(r io.Reader) (*PermissionsDocument, error) {
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	// YAML is a superset of JSON so a single decoder handles both formats
+	doc := &PermissionsDocument{}
+	if err := yaml.Unmarshal(data, doc); err != nil {
+		return nil, err
+	}
+
+	for _, resource := range doc.Resources {
+		if !resource.Resource.Valid() {
+			return nil, &simpleError{fmt.Sprintf("Unknown resource %s", resource.Resource)}
+		}
+		if resource.Resource != GlobalResource && resource.Key == "" {
+			return nil, &simpleError{fmt.Sprintf("Resource %s is missing a key", resource.Resource)}
+		}
+		if err := resource.validate(); err != nil {
+			return nil, err
+		}
+	}
+
+	return doc, nil
+}
+
+// SyncPermissions compares the permissions in the document against the server and applies the difference.
+// The planned changes are returned, and printed to opts.Out if set. With opts.DryRun nothing is applied.
+func (p *Permissions) SyncPermissions(doc *PermissionsDocument, opts *SyncOptions) ([]*PermissionChange, error) {
+	if opts == nil {
+		opts = &SyncOptions{}
+	}
+
+	changes := []*PermissionChange{}
+	for _, desired := range doc.Resources {
+		actual, err := p.ResourcePermissions(desired.Opts())
+		if err != nil {
+			return nil, err
+		}
+		changes = append(changes, DiffPermissions(desired, actual, opts.Prune)...)
+	}
+
+	if opts.Out != nil {
+		for _, c := range changes {
+			fmt.Fprintln(opts.Out, c)
+		}
+	}
+
+	if opts.DryRun {
+		return changes, nil
+	}
+
+	return changes, p.ApplyPermissionChanges(changes)
+}
@@ -0,0 +1,118 @@
+package bamboo_test
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	bamboo "github.com/rcarmstrong/go-bamboo"
+)
+
+const testPermissionsDocument = `
+resources:
+  - resource: plan
+    key: CORE-TEST
+    users:
+      alice: [READ, BUILD]
+    groups:
+      developers: [READ]
+    roles:
+      LOGGED_IN: [READ]
+`
+
+// permissionsRecorder serves a fixed set of plan permissions and records every change request it receives
+type permissionsRecorder struct {
+	mu    sync.Mutex
+	calls []string
+}
+
+func (pr *permissionsRecorder) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	path := strings.TrimPrefix(r.URL.Path, "/rest/api/latest/permissions/")
+
+	if r.Method != http.MethodGet {
+		body, _ := ioutil.ReadAll(r.Body)
+		pr.mu.Lock()
+		pr.calls = append(pr.calls, fmt.Sprintf("%s %s %s", r.Method, path, strings.TrimSpace(string(body))))
+		pr.mu.Unlock()
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	switch {
+	case strings.HasSuffix(path, "/users"):
+		fmt.Fprint(w, `{"results":[{"name":"alice","permissions":["READ","WRITE"]},{"name":"bob","permissions":["READ"]}]}`)
+	case strings.HasSuffix(path, "/groups"):
+		fmt.Fprint(w, `{"results":[{"name":"developers","permissions":["READ"]}]}`)
+	case strings.HasSuffix(path, "/roles"):
+		fmt.Fprint(w, `{"results":[{"name":"LOGGED_IN","permissions":[]},{"name":"ANONYMOUS","permissions":["READ"]}]}`)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func TestParsePermissionsDocument(t *testing.T) {
+	doc, err := bamboo.ParsePermissionsDocument(strings.NewReader(testPermissionsDocument))
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(doc.Resources))
+	assert.Equal(t, []bamboo.Permission{"READ", "BUILD"}, doc.Resources[0].Users["alice"])
+
+	doc, err = bamboo.ParsePermissionsDocument(strings.NewReader(`{"resources":[{"resource":"project","key":"CORE","groups":{"admins":["ADMINISTRATION"]}}]}`))
+	assert.NoError(t, err)
+	assert.Equal(t, []bamboo.Permission{"ADMINISTRATION"}, doc.Resources[0].Groups["admins"])
+
+	_, err = bamboo.ParsePermissionsDocument(strings.NewReader(`{"resources":[{"resource":"unknown","key":"CORE"}]}`))
+	assert.Error(t, err)
+
+	_, err = bamboo.ParsePermissionsDocument(strings.NewReader(`{"resources":[{"resource":"plan","key":"CORE-TEST","roles":{"ANONYMOUS":["BUILD"]}}]}`))
+	assert.Error(t, err)
+}
+
+func TestSyncPermissionsDryRun(t *testing.T) {
+	recorder := &permissionsRecorder{}
+	ts := httptest.NewServer(recorder)
+	defer ts.Close()
+
+	client := bamboo.NewSimpleClient(nil, "", "")
+	client.SetURL(ts.URL)
+
+	doc, err := bamboo.ParsePermissionsDocument(strings.NewReader(testPermissionsDocument))
+	assert.NoError(t, err)
+
+	out := &bytes.Buffer{}
+	changes, err := client.Permissions.SyncPermissions(doc, &bamboo.SyncOptions{DryRun: true, Prune: true, Out: out})
+	assert.NoError(t, err)
+	assert.Empty(t, recorder.calls)
+
+	assert.Equal(t, "plan CORE-TEST: user alice: grant [BUILD], revoke [WRITE]\n"+
+		"plan CORE-TEST: user bob: revoke [READ]\n"+
+		"plan CORE-TEST: role LOGGED_IN: grant [READ]\n"+
+		"plan CORE-TEST: role ANONYMOUS: revoke [READ]\n", out.String())
+	assert.Equal(t, 4, len(changes))
+}
+
+func TestSyncPermissions(t *testing.T) {
+	recorder := &permissionsRecorder{}
+	ts := httptest.NewServer(recorder)
+	defer ts.Close()
+
+	client := bamboo.NewSimpleClient(nil, "", "")
+	client.SetURL(ts.URL)
+
+	doc, err := bamboo.ParsePermissionsDocument(strings.NewReader(testPermissionsDocument))
+	assert.NoError(t, err)
+
+	_, err = client.Permissions.SyncPermissions(doc, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		`PUT plan/CORE-TEST/users/alice ["BUILD"]`,
+		`DELETE plan/CORE-TEST/users/alice ["WRITE"]`,
+		`PUT plan/CORE-TEST/roles/LOGGED_IN ["READ"]`,
+	}, recorder.calls)
+}
@@ -1,6 +1,14 @@
 package bamboo_test
 
-import bamboo "github.com/rcarmstrong/go-bamboo"
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	bamboo "github.com/rcarmstrong/go-bamboo"
+)
 
 var (
 	permissionsTestCases = []bamboo.PermissionsOpts{
@@ -61,3 +69,76 @@ var (
 		[]string{bamboo.CreatePermission, bamboo.AdminPermission},
 	}
 )
+
+// typed converts permission strings to Permissions
+func typed(permissions []string) []bamboo.Permission {
+	converted := make([]bamboo.Permission, len(permissions))
+	for i, permission := range permissions {
+		converted[i] = bamboo.Permission(permission)
+	}
+	return converted
+}
+
+func TestValidatePermissions(t *testing.T) {
+	for _, levels := range allowedPlanAccessLevels {
+		assert.NoError(t, bamboo.ValidatePermissions(bamboo.PlanResource, typed(levels)))
+	}
+	for _, levels := range allowedProjectAccessLevels {
+		assert.NoError(t, bamboo.ValidatePermissions(bamboo.ProjectResource, typed(levels)))
+	}
+
+	assert.NoError(t, bamboo.ValidatePermissions(bamboo.EnvironmentResource, []bamboo.Permission{bamboo.ViewConfigurationPermission, bamboo.DeployPermission}))
+	assert.Error(t, bamboo.ValidatePermissions(bamboo.EnvironmentResource, []bamboo.Permission{bamboo.ClonePermission}))
+	assert.Error(t, bamboo.ValidatePermissions(bamboo.RepositoryResource, []bamboo.Permission{bamboo.BuildPermission}))
+	assert.Error(t, bamboo.ValidatePermissions(bamboo.ResourceType("branch"), []bamboo.Permission{bamboo.ReadPermission}))
+
+	assert.NoError(t, bamboo.ValidateRolePermissions(bamboo.AnonymousRole, bamboo.PlanResource, []bamboo.Permission{bamboo.ReadPermission}))
+	assert.Error(t, bamboo.ValidateRolePermissions(bamboo.AnonymousRole, bamboo.PlanResource, []bamboo.Permission{bamboo.BuildPermission}))
+	assert.NoError(t, bamboo.ValidateRolePermissions(bamboo.LoggedInRole, bamboo.PlanResource, []bamboo.Permission{bamboo.BuildPermission}))
+}
+
+func TestInvalidPermissionsRejectedBeforeRequest(t *testing.T) {
+	var requests []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer ts.Close()
+
+	client := bamboo.NewSimpleClient(nil, "", "")
+	client.SetURL(ts.URL)
+
+	opts := bamboo.PermissionsOpts{Resource: bamboo.EnvironmentResource, Key: "1"}
+
+	_, err := client.Permissions.SetUserPermissions("alice", []string{bamboo.ClonePermission}, opts)
+	assert.Error(t, err)
+	_, err = client.Permissions.GrantGroupPermissions("developers", []bamboo.Permission{bamboo.ClonePermission}, opts)
+	assert.Error(t, err)
+	_, err = client.Permissions.GrantLoggedInUsersPermissions([]bamboo.Permission{bamboo.ClonePermission}, opts)
+	assert.Error(t, err)
+	assert.Empty(t, requests)
+
+	// Anything the server reports can be revoked, even if it isn't known to be grantable
+	_, err = client.Permissions.RemoveGroupPermissions("developers", []string{"SOMETHINGNEW"}, opts)
+	assert.NoError(t, err)
+	_, err = client.Permissions.RevokeUserPermissions("alice", []bamboo.Permission{bamboo.ClonePermission}, opts)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		"DELETE /rest/api/latest/permissions/environment/1/groups/developers",
+		"DELETE /rest/api/latest/permissions/environment/1/users/alice",
+	}, requests)
+
+	// The resource is still checked before revoking
+	requests = nil
+	resource := "nonsense"
+	unknown := bamboo.PermissionsOpts{Resource: resource, Key: "1"}
+	_, err = client.Permissions.RemoveUserPermissions("alice", []string{bamboo.ReadPermission}, unknown)
+	assert.Error(t, err)
+	_, err = client.Permissions.RevokeGroupPermissions("developers", []bamboo.Permission{bamboo.ReadPermission}, unknown)
+	assert.Error(t, err)
+	_, err = client.Permissions.RemoveLoggedInUsersPermissions([]string{bamboo.ReadPermission}, unknown)
+	assert.Error(t, err)
+	_, err = client.Permissions.RemoveAnonymousReadPermission(unknown)
+	assert.Error(t, err)
+	assert.Empty(t, requests)
+}
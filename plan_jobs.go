@@ -0,0 +1,72 @@
+package bamboo
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// PlanJob is a single job of a plan
+type PlanJob struct {
+	Key       string `json:"key"`
+	Name      string `json:"shortName"`
+	StageName string `json:"-"`
+}
+
+type planStagesResponse struct {
+	Stages struct {
+		Stage []struct {
+			Name  string `json:"name"`
+			Plans struct {
+				Plan []*PlanJob `json:"plan"`
+			} `json:"plans"`
+		} `json:"stage"`
+	} `json:"stages"`
+}
+
+// PlanJobs returns the jobs of every stage of the given plan, in stage order
+func (p *PlanService) PlanJobs(planKey string) ([]*PlanJob, *http.Response, error) {
+	request, err := p.client.NewRequest(http.MethodGet, fmt.Sprintf("plan/%s.json?expand=stages.stage.plans", planKey), nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	data := planStagesResponse{}
+	response, err := p.client.Do(request, &data)
+	if err != nil {
+		return nil, response, err
+	}
+
+	if response.StatusCode != 200 {
+		return nil, response, &simpleError{fmt.Sprintf("Retrieving jobs of plan %s returned %s", planKey, response.Status)}
+	}
+
+	jobs := []*PlanJob{}
+	for _, stage := range data.Stages.Stage {
+		for _, job := range stage.Plans.Plan {
+			job.StageName = stage.Name
+			jobs = append(jobs, job)
+		}
+	}
+
+	return jobs, response, nil
+}
+
+// JobRequirements returns the agent requirements of the given job
+func (p *PlanService) JobRequirements(jobKey string) ([]*Requirement, *http.Response, error) {
+	request, err := p.client.NewRequest(http.MethodGet, fmt.Sprintf("config/job/%s/requirement", jobKey), nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	requirements := []*Requirement{}
+	response, err := p.client.Do(request, &requirements)
+	if err != nil {
+		return nil, response, err
+	}
+
+	if response.StatusCode != 200 {
+		return nil, response, &simpleError{fmt.Sprintf("Retrieving requirements of job %s returned %s", jobKey, response.Status)}
+	}
+
+	return requirements, response, nil
+}
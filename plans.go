@@ -188,3 +188,73 @@ func (p *PlanService) DisablePlan(planKey string) (*http.Response, error) {
 	}
 	return response, nil
 }
+
+// PlanVariables returns the variables defined on the given plan or plan branch
+func (p *PlanService) PlanVariables(planKey string) ([]*Variable, *http.Response, error) {
+	if emptyStrings(planKey) {
+		return nil, nil, &simpleError{"Plan key cannot be an empty string"}
+	}
+
+	request, err := p.client.NewRequest(http.MethodGet, fmt.Sprintf("plan/%s/variables", planKey), nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	variables := []*Variable{}
+	response, err := p.client.Do(request, &variables)
+	if err != nil {
+		return nil, response, err
+	}
+
+	if response.StatusCode != 200 {
+		return nil, response, &simpleError{fmt.Sprintf("Listing variables for plan %s returned %s", planKey, response.Status)}
+	}
+
+	return variables, response, nil
+}
+
+// SetPlanVariable creates the variable on the given plan or plan branch, or updates its value if it already exists
+func (p *PlanService) SetPlanVariable(planKey string, variable *Variable) (*http.Response, error) {
+	if variable == nil || emptyStrings(planKey, variable.Name) {
+		return nil, &simpleError{"Plan key and/or variable name cannot be empty"}
+	}
+
+	request, err := p.client.NewRequest(http.MethodPut, planVariableURL(planKey, variable.Name), variable)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := p.client.Do(request, nil)
+	if err != nil {
+		return response, err
+	}
+
+	if response.StatusCode != 200 && response.StatusCode != 204 {
+		return response, &simpleError{fmt.Sprintf("Setting variable %s for plan %s returned %s", variable.Name, planKey, response.Status)}
+	}
+
+	return response, nil
+}
+
+// DeletePlanVariable removes the named variable from the given plan or plan branch
+func (p *PlanService) DeletePlanVariable(planKey, name string) (*http.Response, error) {
+	if emptyStrings(planKey, name) {
+		return nil, &simpleError{"Plan key and/or variable name cannot be empty"}
+	}
+
+	request, err := p.client.NewRequest(http.MethodDelete, planVariableURL(planKey, name), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := p.client.Do(request, nil)
+	if err != nil {
+		return response, err
+	}
+
+	if response.StatusCode != 200 && response.StatusCode != 204 {
+		return response, &simpleError{fmt.Sprintf("Deleting variable %s for plan %s returned %s", name, planKey, response.Status)}
+	}
+
+	return response, nil
+}
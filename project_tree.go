@@ -0,0 +1,118 @@
+package bamboo
+
+import (
+	"net/http"
+)
+
+// ProjectTreeOptions specifies the optional parameters for the ProjectTree method
+// - Concurrency: Maximum number of concurrent requests made while building the tree
+type ProjectTreeOptions struct {
+	Concurrency int
+}
+
+// ProjectNode is a project and its plans
+type ProjectNode struct {
+	Project *Project    `json:"project"`
+	Plans   []*PlanNode `json:"plans"`
+}
+
+// PlanNode is a plan, its latest result and its branches.
+// LatestResult is nil if the plan has never been built.
+type PlanNode struct {
+	Plan         *Plan         `json:"plan"`
+	LatestResult *Result       `json:"latestResult,omitempty"`
+	Branches     []*BranchNode `json:"branches"`
+}
+
+// BranchNode is a plan branch and its latest result.
+// LatestResult is nil if the branch has never been built.
+type BranchNode struct {
+	Branch       *Branch `json:"branch"`
+	LatestResult *Result `json:"latestResult,omitempty"`
+}
+
+// ProjectTree returns every project with its plans, their branches and the latest result of each plan and branch.
+// Requests are made concurrently with at most opts.Concurrency requests in flight.
+func (p *ProjectService) ProjectTree(opts *ProjectTreeOptions) ([]*ProjectNode, error) {
+	concurrency := 0
+	if opts != nil {
+		concurrency = opts.Concurrency
+	}
+
+	projects, _, err := p.ListProjects()
+	if err != nil {
+		return nil, err
+	}
+
+	tree := make([]*ProjectNode, len(projects))
+	err = forEach(len(projects), concurrency, func(i int) error {
+		plans, _, err := p.ProjectPlans(projects[i].Key)
+		if err != nil {
+			return err
+		}
+
+		node := &ProjectNode{Project: projects[i], Plans: make([]*PlanNode, len(plans))}
+		for j, plan := range plans {
+			node.Plans[j] = &PlanNode{Plan: plan}
+		}
+		tree[i] = node
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	planNodes := []*PlanNode{}
+	for _, project := range tree {
+		planNodes = append(planNodes, project.Plans...)
+	}
+
+	err = forEach(len(planNodes), concurrency, func(i int) error {
+		node := planNodes[i]
+		branches, _, err := p.client.Branches.ListPlanBranches(node.Plan.Key)
+		if err != nil {
+			return err
+		}
+
+		node.Branches = make([]*BranchNode, len(branches))
+		for j, branch := range branches {
+			node.Branches[j] = &BranchNode{Branch: branch}
+		}
+
+		node.LatestResult, err = p.latestResult(node.Plan.Key)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	branchNodes := []*BranchNode{}
+	for _, plan := range planNodes {
+		branchNodes = append(branchNodes, plan.Branches...)
+	}
+
+	err = forEach(len(branchNodes), concurrency, func(i int) error {
+		node := branchNodes[i]
+		if node.Branch.PlanKey == nil || node.Branch.Key == "" {
+			return nil
+		}
+
+		result, err := p.latestResult(node.Branch.Key)
+		node.LatestResult = result
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return tree, nil
+}
+
+// latestResult returns the latest result of the given plan or branch, or nil if it has never been built
+func (p *ProjectService) latestResult(key string) (*Result, error) {
+	result, response, err := p.client.Results.LatestResult(key)
+	if response != nil && response.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+	return result, err
+}
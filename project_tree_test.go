@@ -0,0 +1,57 @@
+package bamboo_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	bamboo "github.com/rcarmstrong/go-bamboo"
+)
+
+func TestProjectTree(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(projectTreeStub))
+	defer ts.Close()
+
+	client := bamboo.NewSimpleClient(nil, "", "")
+	client.SetURL(ts.URL)
+
+	tree, err := client.Projects.ProjectTree(&bamboo.ProjectTreeOptions{Concurrency: 2})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(tree))
+
+	core := tree[0]
+	assert.Equal(t, "CORE", core.Project.Key)
+	assert.Equal(t, 1, len(core.Plans))
+	assert.Equal(t, 12, core.Plans[0].LatestResult.BuildNumber)
+	assert.Equal(t, 1, len(core.Plans[0].Branches))
+	assert.Equal(t, 3, core.Plans[0].Branches[0].LatestResult.BuildNumber)
+
+	web := tree[1]
+	assert.Equal(t, 1, len(web.Plans))
+	assert.Nil(t, web.Plans[0].LatestResult)
+	assert.Equal(t, 0, len(web.Plans[0].Branches))
+}
+
+func projectTreeStub(w http.ResponseWriter, r *http.Request) {
+	switch r.URL.Path {
+	case "/rest/api/latest/project.json":
+		fmt.Fprint(w, `{"projects":{"project":[{"key":"CORE"},{"key":"WEB"}]}}`)
+	case "/rest/api/latest/project/CORE.json":
+		fmt.Fprint(w, `{"plans":{"plan":[{"key":"CORE-TEST"}]}}`)
+	case "/rest/api/latest/project/WEB.json":
+		fmt.Fprint(w, `{"plans":{"plan":[{"key":"WEB-SITE"}]}}`)
+	case "/rest/api/latest/plan/CORE-TEST/.json":
+		fmt.Fprint(w, `{"branches":{"branch":[{"key":"CORE-TEST1","shortName":"feature"}]}}`)
+	case "/rest/api/latest/plan/WEB-SITE/.json":
+		fmt.Fprint(w, `{"branches":{"branch":[]}}`)
+	case "/rest/api/latest/result/CORE-TEST-latest":
+		fmt.Fprint(w, `{"key":"CORE-TEST-12","buildNumber":12}`)
+	case "/rest/api/latest/result/CORE-TEST1-latest":
+		fmt.Fprint(w, `{"key":"CORE-TEST1-3","buildNumber":3}`)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
@@ -0,0 +1,208 @@
+package bamboo
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// Variable sources in increasing order of precedence
+const (
+	GlobalVariableSource  = "global"
+	ProjectVariableSource = "project"
+	PlanVariableSource    = "plan"
+	BranchVariableSource  = "branch"
+)
+
+// ResolvedVariable is a variable in the effective variable set of a plan
+// - Source:     The level the value was taken from
+// - Overridden: The lower precedence levels that also defined the variable
+type ResolvedVariable struct {
+	Variable
+	Source     string   `json:"source"`
+	Overridden []string `json:"overridden,omitempty"`
+}
+
+// variableLayer is the set of variables defined at a single level of the variable hierarchy
+type variableLayer struct {
+	source    string
+	variables []*Variable
+}
+
+// ProjectVariables returns the variables defined on the given project
+func (p *ProjectService) ProjectVariables(projectKey string) ([]*Variable, *http.Response, error) {
+	if emptyStrings(projectKey) {
+		return nil, nil, &simpleError{"Project key cannot be an empty string"}
+	}
+
+	request, err := p.client.NewRequest(http.MethodGet, fmt.Sprintf("project/%s/variables", projectKey), nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	variables := []*Variable{}
+	response, err := p.client.Do(request, &variables)
+	if err != nil {
+		return nil, response, err
+	}
+
+	if response.StatusCode != http.StatusOK {
+		return nil, response, &simpleError{fmt.Sprintf("Listing variables for project %s returned %s", projectKey, response.Status)}
+	}
+
+	return variables, response, nil
+}
+
+// ProjectVariable returns the named variable of the given project
+func (p *ProjectService) ProjectVariable(projectKey, name string) (*Variable, *http.Response, error) {
+	if emptyStrings(projectKey, name) {
+		return nil, nil, &simpleError{"Project key and/or variable name cannot be empty"}
+	}
+
+	request, err := p.client.NewRequest(http.MethodGet, projectVariableURL(projectKey, name), nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	variable := Variable{}
+	response, err := p.client.Do(request, &variable)
+	if err != nil {
+		return nil, response, err
+	}
+
+	if response.StatusCode != http.StatusOK {
+		return nil, response, &simpleError{fmt.Sprintf("Getting variable %s for project %s returned %s", name, projectKey, response.Status)}
+	}
+
+	return &variable, response, nil
+}
+
+// CreateProjectVariable adds a new variable to the given project
+func (p *ProjectService) CreateProjectVariable(projectKey string, variable *Variable) (*http.Response, error) {
+	if variable == nil || emptyStrings(projectKey, variable.Name) {
+		return nil, &simpleError{"Project key and/or variable name cannot be empty"}
+	}
+
+	request, err := p.client.NewRequest(http.MethodPost, fmt.Sprintf("project/%s/variables", projectKey), variable)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := p.client.Do(request, nil)
+	if err != nil {
+		return response, err
+	}
+
+	if response.StatusCode != http.StatusOK && response.StatusCode != http.StatusCreated {
+		return response, &simpleError{fmt.Sprintf("Creating variable %s for project %s returned %s", variable.Name, projectKey, response.Status)}
+	}
+
+	return response, nil
+}
+
+// UpdateProjectVariable changes the value of an existing variable of the given project
+func (p *ProjectService) UpdateProjectVariable(projectKey string, variable *Variable) (*http.Response, error) {
+	if variable == nil || emptyStrings(projectKey, variable.Name) {
+		return nil, &simpleError{"Project key and/or variable name cannot be empty"}
+	}
+
+	request, err := p.client.NewRequest(http.MethodPut, projectVariableURL(projectKey, variable.Name), variable)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := p.client.Do(request, nil)
+	if err != nil {
+		return response, err
+	}
+
+	if response.StatusCode != http.StatusOK && response.StatusCode != http.StatusNoContent {
+		return response, &simpleError{fmt.Sprintf("Updating variable %s for project %s returned %s", variable.Name, projectKey, response.Status)}
+	}
+
+	return response, nil
+}
+
+// DeleteProjectVariable removes the named variable from the given project
+func (p *ProjectService) DeleteProjectVariable(projectKey, name string) (*http.Response, error) {
+	if emptyStrings(projectKey, name) {
+		return nil, &simpleError{"Project key and/or variable name cannot be empty"}
+	}
+
+	request, err := p.client.NewRequest(http.MethodDelete, projectVariableURL(projectKey, name), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := p.client.Do(request, nil)
+	if err != nil {
+		return response, err
+	}
+
+	if response.StatusCode != http.StatusOK && response.StatusCode != http.StatusNoContent {
+		return response, &simpleError{fmt.Sprintf("Deleting variable %s for project %s returned %s", name, projectKey, response.Status)}
+	}
+
+	return response, nil
+}
+
+// EffectiveVariables computes the variables a build of the given plan sees by layering global,
+// project, plan and, if branchKey is not empty, plan branch variables. Later levels override
+// earlier ones. The result is sorted by variable name.
+func (p *ProjectService) EffectiveVariables(planKey, branchKey string) ([]*ResolvedVariable, error) {
+	if emptyStrings(planKey) {
+		return nil, &simpleError{"Plan key cannot be an empty string"}
+	}
+	projectKey := strings.SplitN(planKey, "-", 2)[0]
+
+	global, _, err := p.client.Server.GlobalVariables()
+	if err != nil {
+		return nil, err
+	}
+
+	project, _, err := p.ProjectVariables(projectKey)
+	if err != nil {
+		return nil, err
+	}
+
+	plan, _, err := p.client.Plans.PlanVariables(planKey)
+	if err != nil {
+		return nil, err
+	}
+
+	layers := []variableLayer{
+		{GlobalVariableSource, global},
+		{ProjectVariableSource, project},
+		{PlanVariableSource, plan},
+	}
+
+	if branchKey != "" {
+		branch, _, err := p.client.Plans.PlanVariables(branchKey)
+		if err != nil {
+			return nil, err
+		}
+		layers = append(layers, variableLayer{BranchVariableSource, branch})
+	}
+
+	resolved := map[string]*ResolvedVariable{}
+	for _, layer := range layers {
+		for _, v := range layer.variables {
+			if existing, ok := resolved[v.Name]; ok {
+				existing.Overridden = append(existing.Overridden, existing.Source)
+				existing.Value = v.Value
+				existing.Source = layer.source
+				continue
+			}
+			resolved[v.Name] = &ResolvedVariable{Variable: *v, Source: layer.source}
+		}
+	}
+
+	effective := make([]*ResolvedVariable, 0, len(resolved))
+	for _, v := range resolved {
+		effective = append(effective, v)
+	}
+	sort.Slice(effective, func(i, j int) bool { return effective[i].Name < effective[j].Name })
+
+	return effective, nil
+}
@@ -0,0 +1,130 @@
+package bamboo_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	bamboo "github.com/rcarmstrong/go-bamboo"
+)
+
+func TestCreateProjectVariable(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(createProjectVariableStub))
+	defer ts.Close()
+
+	client := bamboo.NewSimpleClient(nil, "", "")
+	client.SetURL(ts.URL)
+
+	response, err := client.Projects.CreateProjectVariable("CORE", &bamboo.Variable{Name: "region", Value: "us-east-1"})
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusCreated, response.StatusCode)
+
+	_, err = client.Projects.CreateProjectVariable("CORE", &bamboo.Variable{})
+	assert.Error(t, err)
+}
+
+func createProjectVariableStub(w http.ResponseWriter, r *http.Request) {
+	variable := &bamboo.Variable{}
+	json.NewDecoder(r.Body).Decode(variable)
+
+	if r.Method != http.MethodPost || r.URL.Path != "/rest/api/latest/project/CORE/variables" || variable.Value != "us-east-1" {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	w.WriteHeader(http.StatusCreated)
+}
+
+func TestProjectVariableChanges(t *testing.T) {
+	var requests []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.EscapedPath())
+		switch {
+		case r.URL.EscapedPath() == "/rest/api/latest/project/CORE/variables/deploy.region" && r.Method == http.MethodGet:
+			fmt.Fprint(w, `{"name":"deploy.region","value":"us-east-1"}`)
+		case r.URL.EscapedPath() == "/rest/api/latest/project/CORE/variables/deploy.region":
+			variable := &bamboo.Variable{}
+			json.NewDecoder(r.Body).Decode(variable)
+			if r.Method == http.MethodPut && variable.Value != "us-west-2" {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	client := bamboo.NewSimpleClient(nil, "", "")
+	client.SetURL(ts.URL)
+
+	variable, _, err := client.Projects.ProjectVariable("CORE", "deploy.region")
+	assert.NoError(t, err)
+	assert.Equal(t, "us-east-1", variable.Value)
+
+	_, err = client.Projects.UpdateProjectVariable("CORE", &bamboo.Variable{Name: "deploy.region", Value: "us-west-2"})
+	assert.NoError(t, err)
+
+	_, err = client.Projects.DeleteProjectVariable("CORE", "deploy.region")
+	assert.NoError(t, err)
+
+	// Single variables live under the same collection they are listed and created in
+	assert.Equal(t, []string{
+		"GET /rest/api/latest/project/CORE/variables/deploy.region",
+		"PUT /rest/api/latest/project/CORE/variables/deploy.region",
+		"DELETE /rest/api/latest/project/CORE/variables/deploy.region",
+	}, requests)
+
+	_, err = client.Projects.DeleteProjectVariable("CORE", "missing")
+	assert.Error(t, err)
+	_, err = client.Projects.UpdateProjectVariable("CORE", &bamboo.Variable{})
+	assert.Error(t, err)
+}
+
+func TestEffectiveVariables(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(effectiveVariablesStub))
+	defer ts.Close()
+
+	client := bamboo.NewSimpleClient(nil, "", "")
+	client.SetURL(ts.URL)
+
+	variables, err := client.Projects.EffectiveVariables("CORE-TEST", "CORE-TEST3")
+	assert.NoError(t, err)
+
+	resolved := map[string]*bamboo.ResolvedVariable{}
+	for _, v := range variables {
+		resolved[v.Name] = v
+	}
+	assert.Equal(t, 4, len(resolved))
+
+	assert.Equal(t, "global", resolved["owner"].Value)
+	assert.Equal(t, bamboo.GlobalVariableSource, resolved["owner"].Source)
+
+	assert.Equal(t, "eu-west-1", resolved["region"].Value)
+	assert.Equal(t, bamboo.PlanVariableSource, resolved["region"].Source)
+	assert.Equal(t, []string{bamboo.GlobalVariableSource, bamboo.ProjectVariableSource}, resolved["region"].Overridden)
+
+	assert.Equal(t, "feature", resolved["tier"].Value)
+	assert.Equal(t, bamboo.BranchVariableSource, resolved["tier"].Source)
+
+	assert.Equal(t, bamboo.ProjectVariableSource, resolved["team"].Source)
+}
+
+func effectiveVariablesStub(w http.ResponseWriter, r *http.Request) {
+	switch r.URL.Path {
+	case "/rest/api/latest/admin/globalVariables":
+		fmt.Fprint(w, `[{"name":"owner","value":"global"},{"name":"region","value":"us-east-1"}]`)
+	case "/rest/api/latest/project/CORE/variables":
+		fmt.Fprint(w, `[{"name":"region","value":"us-west-2"},{"name":"team","value":"core"}]`)
+	case "/rest/api/latest/plan/CORE-TEST/variables":
+		fmt.Fprint(w, `[{"name":"region","value":"eu-west-1"},{"name":"tier","value":"prod"}]`)
+	case "/rest/api/latest/plan/CORE-TEST3/variables":
+		fmt.Fprint(w, `[{"name":"tier","value":"feature"}]`)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
@@ -4,8 +4,13 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"regexp"
 )
 
+// projectKeyPattern is the format Bamboo requires of project keys: an uppercase letter
+// followed by one or more uppercase letters or digits
+var projectKeyPattern = regexp.MustCompile(`^[A-Z][A-Z0-9]+$`)
+
 // ProjectService handles communication with the project related methods
 type ProjectService service
 
@@ -29,6 +34,12 @@ type Project struct {
 	Link        *Link  `json:"link,omitempty"`
 }
 
+// projectUpdate is the body of an UpdateProject request. Description is sent even when empty so it can be cleared.
+type projectUpdate struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
 // ProjectInformation is the information for a single project
 type ProjectInformation struct {
 	Key         string                   `json:"key,omitempty"`
@@ -124,3 +135,98 @@ func (p *ProjectService) ListProjects() ([]*Project, *http.Response, error) {
 
 	return projectResp.Projects.ProjectList, response, nil
 }
+
+// ValidateProjectKey checks that the given key satisfies Bamboo's project key format rules
+func ValidateProjectKey(projectKey string) error {
+	if !projectKeyPattern.MatchString(projectKey) {
+		return &simpleError{fmt.Sprintf("Invalid project key %q: must be an uppercase letter followed by one or more uppercase letters or digits", projectKey)}
+	}
+	return nil
+}
+
+// CreateProject creates a new project with the key, name and description of the given project
+func (p *ProjectService) CreateProject(project *Project) (*Project, *http.Response, error) {
+	if project == nil || emptyStrings(project.Name) {
+		return nil, nil, &simpleError{"Project name cannot be an empty string"}
+	}
+	if err := ValidateProjectKey(project.Key); err != nil {
+		return nil, nil, err
+	}
+
+	request, err := p.client.NewRequest(http.MethodPost, "project", &Project{
+		Key:         project.Key,
+		Name:        project.Name,
+		Description: project.Description,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	created := Project{}
+	response, err := p.client.Do(request, &created)
+	if err != nil {
+		return nil, response, err
+	}
+
+	if response.StatusCode != http.StatusOK && response.StatusCode != http.StatusCreated {
+		return nil, response, &simpleError{fmt.Sprintf("Creating project %s returned %s", project.Key, response.Status)}
+	}
+
+	return &created, response, nil
+}
+
+// UpdateProject changes the name and description of the project with the given project's key.
+// An empty description clears the project's description.
+func (p *ProjectService) UpdateProject(project *Project) (*Project, *http.Response, error) {
+	if project == nil || emptyStrings(project.Key) {
+		return nil, nil, &simpleError{"Project key cannot be an empty string"}
+	}
+
+	request, err := p.client.NewRequest(http.MethodPut, fmt.Sprintf("project/%s", project.Key), &projectUpdate{
+		Name:        project.Name,
+		Description: project.Description,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	updated := Project{}
+	response, err := p.client.Do(request, &updated)
+	if err != nil {
+		return nil, response, err
+	}
+
+	if response.StatusCode != http.StatusOK {
+		return nil, response, &simpleError{fmt.Sprintf("Updating project %s returned %s", project.Key, response.Status)}
+	}
+
+	return &updated, response, nil
+}
+
+// DeleteProject deletes the given project. Projects that still contain plans are not deleted.
+func (p *ProjectService) DeleteProject(projectKey string) (*http.Response, error) {
+	info, response, err := p.ProjectInfo(projectKey)
+	if err != nil {
+		return response, err
+	}
+
+	if info.NumPlans != nil && info.NumPlans.Size > 0 {
+		return response, &simpleError{fmt.Sprintf("Project %s still contains %d plans", projectKey, info.NumPlans.Size)}
+	}
+
+	request, err := p.client.NewRequest(http.MethodDelete, fmt.Sprintf("project/%s", projectKey), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err = p.client.Do(request, nil)
+	if err != nil {
+		return response, err
+	}
+
+	if response.StatusCode != http.StatusOK && response.StatusCode != http.StatusNoContent {
+		return response, &simpleError{fmt.Sprintf("Deleting project %s returned %s", projectKey, response.Status)}
+	}
+
+	return response, nil
+}
@@ -132,6 +132,105 @@ func listProjectsStub(w http.ResponseWriter, r *http.Request) {
 	w.Write(bytes)
 }
 
+func TestValidateProjectKey(t *testing.T) {
+	for _, key := range []string{"AB", "CORE", "A1", "PROJ2020"} {
+		assert.NoError(t, bamboo.ValidateProjectKey(key), key)
+	}
+
+	for _, key := range []string{"", "A", "abc", "1AB", "AB-C", "AB C"} {
+		assert.Error(t, bamboo.ValidateProjectKey(key), key)
+	}
+}
+
+func TestCreateProject(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(createProjectStub))
+	defer ts.Close()
+
+	client := bamboo.NewSimpleClient(nil, "", "")
+	client.SetURL(ts.URL)
+
+	project, response, err := client.Projects.CreateProject(&bamboo.Project{Key: "NEW", Name: "new project"})
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusCreated, response.StatusCode)
+	assert.Equal(t, "NEW", project.Key)
+
+	_, response, err = client.Projects.CreateProject(&bamboo.Project{Key: "new", Name: "new project"})
+	assert.Error(t, err)
+	assert.Nil(t, response)
+}
+
+func createProjectStub(w http.ResponseWriter, r *http.Request) {
+	project := &bamboo.Project{}
+	json.NewDecoder(r.Body).Decode(project)
+
+	if r.Method != http.MethodPost || r.URL.Path != "/rest/api/latest/project" {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	bytes, err := json.Marshal(project)
+	if err != nil {
+		panic(err)
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	w.Write(bytes)
+}
+
+func TestUpdateProject(t *testing.T) {
+	var body map[string]interface{}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut || r.URL.Path != "/rest/api/latest/project/CORE" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		body = map[string]interface{}{}
+		json.NewDecoder(r.Body).Decode(&body)
+		json.NewEncoder(w).Encode(bamboo.Project{Key: "CORE", Name: body["name"].(string)})
+	}))
+	defer ts.Close()
+
+	client := bamboo.NewSimpleClient(nil, "", "")
+	client.SetURL(ts.URL)
+
+	// An empty description is sent so the old one is cleared
+	project, _, err := client.Projects.UpdateProject(&bamboo.Project{Key: "CORE", Name: "Core"})
+	assert.NoError(t, err)
+	assert.Equal(t, "Core", project.Name)
+	assert.Equal(t, map[string]interface{}{"name": "Core", "description": ""}, body)
+
+	_, _, err = client.Projects.UpdateProject(&bamboo.Project{Name: "Core"})
+	assert.Error(t, err)
+}
+
+func TestDeleteProject(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(deleteProjectStub))
+	defer ts.Close()
+
+	client := bamboo.NewSimpleClient(nil, "", "")
+	client.SetURL(ts.URL)
+
+	response, err := client.Projects.DeleteProject("EMPTY")
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusNoContent, response.StatusCode)
+
+	_, err = client.Projects.DeleteProject("FULL")
+	assert.Error(t, err)
+}
+
+func deleteProjectStub(w http.ResponseWriter, r *http.Request) {
+	switch {
+	case r.Method == http.MethodGet && r.URL.Path == "/rest/api/latest/project/EMPTY.json":
+		json.NewEncoder(w).Encode(bamboo.ProjectInformation{Key: "EMPTY", NumPlans: &bamboo.ProjectPlansInformation{Size: 0}})
+	case r.Method == http.MethodGet && r.URL.Path == "/rest/api/latest/project/FULL.json":
+		json.NewEncoder(w).Encode(bamboo.ProjectInformation{Key: "FULL", NumPlans: &bamboo.ProjectPlansInformation{Size: 2}})
+	case r.Method == http.MethodDelete && r.URL.Path == "/rest/api/latest/project/EMPTY":
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusBadRequest)
+	}
+}
+
 func unauthorizedStub(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusUnauthorized)
 }
@@ -0,0 +1,48 @@
+package bamboo
+
+import (
+	"regexp"
+)
+
+// Requirement match types
+const (
+	RequirementExists  = "EXISTS"
+	RequirementEquals  = "EQUALS"
+	RequirementMatches = "MATCHES"
+)
+
+// Requirement is a capability an agent must have to run a job or deployment
+// - Key:        Capability key, e.g. "system.builder.mvn3.Maven 3"
+// - MatchType:  One of RequirementExists, RequirementEquals or RequirementMatches
+// - MatchValue: Value or regular expression the capability must match. Ignored for RequirementExists
+type Requirement struct {
+	ID         int    `json:"id,omitempty"`
+	Key        string `json:"key"`
+	MatchType  string `json:"matchType"`
+	MatchValue string `json:"matchValue,omitempty"`
+}
+
+// SatisfiedBy reports whether any of the capabilities meets the requirement.
+// RequirementMatches values are regular expressions that must match the whole capability value.
+func (r *Requirement) SatisfiedBy(capabilities []*Capability) bool {
+	for _, capability := range capabilities {
+		if capability.Key != r.Key {
+			continue
+		}
+
+		switch r.MatchType {
+		case RequirementExists:
+			return true
+		case RequirementEquals:
+			if capability.Value == r.MatchValue {
+				return true
+			}
+		case RequirementMatches:
+			pattern, err := regexp.Compile("^(?:" + r.MatchValue + ")$")
+			if err == nil && pattern.MatchString(capability.Value) {
+				return true
+			}
+		}
+	}
+	return false
+}
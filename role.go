@@ -40,6 +40,16 @@ func (p *Permissions) RolePermissionsList(opts PermissionsOpts) ([]Role, *http.R
 
 // SetLoggedInUsersPermissions sets the logged in users role's permissions for the given project's plans to the passed in permissions
 func (p *Permissions) SetLoggedInUsersPermissions(permissions []string, opts PermissionsOpts) (*http.Response, error) {
+	return p.GrantLoggedInUsersPermissions(toPermissions(permissions), opts)
+}
+
+// GrantLoggedInUsersPermissions is SetLoggedInUsersPermissions for typed permissions. The permissions are
+// checked with ValidateRolePermissions before any request is made.
+func (p *Permissions) GrantLoggedInUsersPermissions(permissions []Permission, opts PermissionsOpts) (*http.Response, error) {
+	if err := ValidateRolePermissions(LoggedInRole, opts.resourceType(), permissions); err != nil {
+		return nil, err
+	}
+
 	request, err := p.client.NewRequest(http.MethodPut, loggedInRolePermissionsURL(opts.Resource, opts.Key), permissions)
 	if err != nil {
 		return nil, err
@@ -65,6 +75,16 @@ func (p *Permissions) SetLoggedInUsersPermissions(permissions []string, opts Per
 
 // RemoveLoggedInUsersPermissions removes the given permissions from the logged in users role's permissions for the given project's plans
 func (p *Permissions) RemoveLoggedInUsersPermissions(permissions []string, opts PermissionsOpts) (*http.Response, error) {
+	return p.RevokeLoggedInUsersPermissions(toPermissions(permissions), opts)
+}
+
+// RevokeLoggedInUsersPermissions is RemoveLoggedInUsersPermissions for typed permissions. Unlike granting,
+// only the resource is checked, not the permissions, so anything the server reports can be revoked.
+func (p *Permissions) RevokeLoggedInUsersPermissions(permissions []Permission, opts PermissionsOpts) (*http.Response, error) {
+	if !opts.resourceType().Valid() {
+		return nil, &simpleError{fmt.Sprintf("Unknown resource %s", opts.Resource)}
+	}
+
 	request, err := p.client.NewRequest(http.MethodDelete, loggedInRolePermissionsURL(opts.Resource, opts.Key), permissions)
 	if err != nil {
 		return nil, err
@@ -90,7 +110,11 @@ func (p *Permissions) RemoveLoggedInUsersPermissions(permissions []string, opts
 
 // SetAnonymousReadPermission allows anonymous users to view plans
 func (p *Permissions) SetAnonymousReadPermission(opts PermissionsOpts) (*http.Response, error) {
-	request, err := p.client.NewRequest(http.MethodPut, anonymousRolePermissionsURL(opts.Resource, opts.Key), []string{ReadPermission})
+	if err := ValidateRolePermissions(AnonymousRole, opts.resourceType(), []Permission{ReadPermission}); err != nil {
+		return nil, err
+	}
+
+	request, err := p.client.NewRequest(http.MethodPut, anonymousRolePermissionsURL(opts.Resource, opts.Key), []Permission{ReadPermission})
 	if err != nil {
 		return nil, err
 	}
@@ -115,7 +139,11 @@ func (p *Permissions) SetAnonymousReadPermission(opts PermissionsOpts) (*http.Re
 
 // RemoveAnonymousReadPermission removes the ability for anonymous users to view plans
 func (p *Permissions) RemoveAnonymousReadPermission(opts PermissionsOpts) (*http.Response, error) {
-	request, err := p.client.NewRequest(http.MethodDelete, anonymousRolePermissionsURL(opts.Resource, opts.Key), []string{ReadPermission})
+	if !opts.resourceType().Valid() {
+		return nil, &simpleError{fmt.Sprintf("Unknown resource %s", opts.Resource)}
+	}
+
+	request, err := p.client.NewRequest(http.MethodDelete, anonymousRolePermissionsURL(opts.Resource, opts.Key), []Permission{ReadPermission})
 	if err != nil {
 		return nil, err
 	}
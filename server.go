@@ -147,3 +147,24 @@ func (s *ServerService) ReindexStatus() (*ReindexState, *http.Response, error) {
 
 	return state, response, nil
 }
+
+// GlobalVariables returns the global variables inherited by every plan and deployment
+func (s *ServerService) GlobalVariables() ([]*Variable, *http.Response, error) {
+	u := "admin/globalVariables"
+	request, err := s.client.NewRequest(http.MethodGet, u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	variables := []*Variable{}
+	response, err := s.client.Do(request, &variables)
+	if err != nil {
+		return nil, response, err
+	}
+
+	if !(response.StatusCode == 200) {
+		return nil, response, &simpleError{fmt.Sprintf("Request for global variables returned %d", response.StatusCode)}
+	}
+
+	return variables, response, nil
+}
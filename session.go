@@ -0,0 +1,111 @@
+package bamboo
+
+import (
+	"net/http"
+	"net/http/cookiejar"
+	"sync"
+)
+
+// sessionCookie is the cookie Bamboo sets once a request has been authenticated
+const sessionCookie = "JSESSIONID"
+
+// SessionCredentials authenticate with Login once and then reuse the session the server creates,
+// sending its JSESSIONID and atl.xsrf.token cookies instead of the login credentials. This avoids
+// the user directory lookup Bamboo makes for every request carrying a password. When the session
+// expires the server answers 401, the login credentials are sent again and the request is retried
+// transparently. It is safe for concurrent use, although concurrent requests made before the first
+// session exists may each log in.
+type SessionCredentials struct {
+	Login Authorizer
+
+	mu  sync.Mutex
+	jar http.CookieJar
+}
+
+// NewSessionCredentials returns session credentials that log in with login
+func NewSessionCredentials(login Authorizer) *SessionCredentials {
+	return &SessionCredentials{Login: login}
+}
+
+// NewSessionClient returns a new Bamboo API client which logs in with username and password once and
+// uses the session cookies after that. If a nil httpClient is provided, http.DefaultClient will be used.
+func NewSessionClient(httpClient *http.Client, username, password string) *Client {
+	creds := NewSessionCredentials(&SimpleCredentials{Username: username, Password: password})
+	return NewClient(httpClient, creds)
+}
+
+// Authorization returns the Authorization header value of the login credentials. Requests made by the
+// client only carry it while there is no session.
+func (s *SessionCredentials) Authorization() string {
+	return s.Login.Authorization()
+}
+
+// Reset drops the current session so the next request logs in again
+func (s *SessionCredentials) Reset() {
+	s.mu.Lock()
+	s.jar = nil
+	s.mu.Unlock()
+}
+
+func (s *SessionCredentials) cookies() http.CookieJar {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.jar == nil {
+		// cookiejar.New only fails on invalid options
+		s.jar, _ = cookiejar.New(nil)
+	}
+	return s.jar
+}
+
+// AuthorizeRequest adds the session cookies to req, or the login credentials if there is no session yet
+func (s *SessionCredentials) AuthorizeRequest(req *http.Request) error {
+	cookies := s.cookies().Cookies(req.URL)
+	if findCookie(cookies, sessionCookie) == nil {
+		authorization, err := authorizationFor(s.Login)
+		if err != nil {
+			return err
+		}
+		req.Header.Set("Authorization", authorization)
+		return nil
+	}
+
+	for _, cookie := range cookies {
+		req.AddCookie(cookie)
+	}
+	if req.Method != http.MethodGet && req.Method != http.MethodHead {
+		// Cookie authenticated changes are checked for XSRF, which REST clients opt out of
+		req.Header.Set("X-Atlassian-Token", "no-check")
+	}
+	return nil
+}
+
+func (s *SessionCredentials) observe(req *http.Request, resp *http.Response) bool {
+	jar := s.cookies()
+	if cookies := resp.Cookies(); len(cookies) > 0 {
+		jar.SetCookies(req.URL, cookies)
+	}
+
+	sent := findCookie(req.Cookies(), sessionCookie)
+	if resp.StatusCode != http.StatusUnauthorized || sent == nil {
+		return false
+	}
+
+	// Only drop the session this request used, another request may already have logged in again
+	s.mu.Lock()
+	if s.jar != nil {
+		if current := findCookie(s.jar.Cookies(req.URL), sessionCookie); current != nil && current.Value == sent.Value {
+			s.jar = nil
+		}
+	}
+	s.mu.Unlock()
+	return true
+}
+
+func findCookie(cookies []*http.Cookie, name string) *http.Cookie {
+	for _, cookie := range cookies {
+		if cookie.Name == name {
+			return cookie
+		}
+	}
+	return nil
+}
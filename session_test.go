@@ -0,0 +1,84 @@
+package bamboo_test
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	bamboo "github.com/rcarmstrong/go-bamboo"
+)
+
+func TestSessionCredentials(t *testing.T) {
+	var (
+		logins  int
+		session string
+		bodies  []string
+		xsrf    []string
+	)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if username, password, ok := r.BasicAuth(); ok {
+			if username != "admin" || password != "admin" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			logins++
+			session = fmt.Sprintf("session-%d", logins)
+			http.SetCookie(w, &http.Cookie{Name: "JSESSIONID", Value: session, Path: "/"})
+			http.SetCookie(w, &http.Cookie{Name: "atl.xsrf.token", Value: "xsrf-" + session, Path: "/"})
+		} else if cookie, err := r.Cookie("JSESSIONID"); err != nil || cookie.Value != session {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		if r.Method == http.MethodPost {
+			body, _ := ioutil.ReadAll(r.Body)
+			bodies = append(bodies, string(body))
+			xsrf = append(xsrf, r.Header.Get("X-Atlassian-Token"))
+		}
+		w.Write([]byte(`{"name":"admin"}`))
+	}))
+	defer ts.Close()
+
+	client := bamboo.NewSessionClient(nil, "admin", "admin")
+	client.SetURL(ts.URL)
+
+	for i := 0; i < 3; i++ {
+		user, _, err := client.Users.CurrentUser()
+		assert.NoError(t, err)
+		assert.Equal(t, "admin", user.Name)
+	}
+	assert.Equal(t, 1, logins)
+
+	request, err := client.NewRequest(http.MethodGet, "currentUser", nil)
+	assert.NoError(t, err)
+	assert.Empty(t, request.Header.Get("Authorization"))
+
+	// The server expiring the session logs in again and resends the request with its body
+	session = "expired"
+	request, err = client.NewRequest(http.MethodPost, "plan/PROJ-PLAN", map[string]string{"key": "value"})
+	assert.NoError(t, err)
+	response, err := client.Do(request, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+	assert.Equal(t, 2, logins)
+	assert.Equal(t, []string{"{\"key\":\"value\"}\n"}, bodies)
+	assert.Equal(t, []string{""}, xsrf)
+
+	// Changes made with the session opt out of the XSRF check
+	request, err = client.NewRequest(http.MethodPost, "plan/PROJ-PLAN", nil)
+	assert.NoError(t, err)
+	_, err = client.Do(request, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "no-check", xsrf[1])
+	assert.Equal(t, 2, logins)
+
+	// Bad login credentials aren't retried
+	client.SetAuthorizer(bamboo.NewSessionCredentials(&bamboo.SimpleCredentials{Username: "admin", Password: "wrong"}))
+	_, _, err = client.Users.CurrentUser()
+	assert.Error(t, err)
+	assert.Equal(t, 2, logins)
+}
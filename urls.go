@@ -2,6 +2,8 @@ package bamboo
 
 import (
 	"fmt"
+	"net/url"
+	"strconv"
 )
 
 // -- Results --
@@ -15,6 +17,16 @@ func listResultsURL(key string) string {
 	return fmt.Sprintf(resultsBase+"/%s?expand=results.result.artifacts,results.result.comments,results.result.labels,results.result.stages", key)
 }
 
+// -- Projects --
+func projectVariableURL(projectKey, name string) string {
+	return fmt.Sprintf("project/%s/variables/%s", projectKey, url.PathEscape(name))
+}
+
+// -- Plans --
+func planVariableURL(planKey, name string) string {
+	return fmt.Sprintf("plan/%s/variables/%s", planKey, url.PathEscape(name))
+}
+
 // -- Permissions --
 const permissionBase = "permissions/%s"
 
@@ -97,3 +109,15 @@ func anonymousRolePermissionsURL(resource, key string) string {
 	}
 	return fmt.Sprintf(permissionBase+"/%s/roles/ANONYMOUS", resource, key)
 }
+
+// -- Agents --
+func agentAssignmentURL(agentID int, assignment *AgentAssignment) string {
+	values := url.Values{}
+	values.Set("executorType", "AGENT")
+	values.Set("executorId", strconv.Itoa(agentID))
+	if assignment != nil {
+		values.Set("assignmentType", assignment.Type)
+		values.Set("entityId", strconv.Itoa(assignment.EntityID))
+	}
+	return "agent/assignment?" + values.Encode()
+}
@@ -20,7 +20,7 @@ type userPermissionsResponse struct {
 
 // UserPermissionsList returns a list of users and their permissions for the given resource key in the service
 func (p *Permissions) UserPermissionsList(opts PermissionsOpts) ([]User, *http.Response, error) {
-	if !knownResources[opts.Resource] {
+	if !opts.resourceType().Valid() {
 		return nil, nil, &simpleError{fmt.Sprintf("Unknown resource %s", opts.Resource)}
 	}
 
@@ -46,7 +46,7 @@ func (p *Permissions) UserPermissionsList(opts PermissionsOpts) ([]User, *http.R
 
 // UserPermissions returns the permissions for the specified user on the given resource in the given service
 func (p *Permissions) UserPermissions(username string, opts PermissionsOpts) (*User, *http.Response, error) {
-	if !knownResources[opts.Resource] {
+	if !opts.resourceType().Valid() {
 		return nil, nil, &simpleError{fmt.Sprintf("Unknown resource %s", opts.Resource)}
 	}
 
@@ -77,8 +77,14 @@ func (p *Permissions) UserPermissions(username string, opts PermissionsOpts) (*U
 
 // SetUserPermissions sets the users permissions for the given project's plans to the passed in permissions array
 func (p *Permissions) SetUserPermissions(username string, permissions []string, opts PermissionsOpts) (*http.Response, error) {
-	if !knownResources[opts.Resource] {
-		return nil, &simpleError{fmt.Sprintf("Unknown resource %s", opts.Resource)}
+	return p.GrantUserPermissions(username, toPermissions(permissions), opts)
+}
+
+// GrantUserPermissions is SetUserPermissions for typed permissions. The permissions are checked with
+// ValidatePermissions before any request is made.
+func (p *Permissions) GrantUserPermissions(username string, permissions []Permission, opts PermissionsOpts) (*http.Response, error) {
+	if err := ValidatePermissions(opts.resourceType(), permissions); err != nil {
+		return nil, err
 	}
 
 	request, err := p.client.NewRequest(http.MethodPut, editUserPermissionsURL(opts.Resource, opts.Key, username), permissions)
@@ -108,7 +114,13 @@ func (p *Permissions) SetUserPermissions(username string, permissions []string,
 
 // RemoveUserPermissions removes the given permissions from the users permissions for the given project's plans
 func (p *Permissions) RemoveUserPermissions(username string, permissions []string, opts PermissionsOpts) (*http.Response, error) {
-	if !knownResources[opts.Resource] {
+	return p.RevokeUserPermissions(username, toPermissions(permissions), opts)
+}
+
+// RevokeUserPermissions is RemoveUserPermissions for typed permissions. Unlike granting, only the
+// resource is checked, not the permissions, so anything the server reports can be revoked.
+func (p *Permissions) RevokeUserPermissions(username string, permissions []Permission, opts PermissionsOpts) (*http.Response, error) {
+	if !opts.resourceType().Valid() {
 		return nil, &simpleError{fmt.Sprintf("Unknown resource %s", opts.Resource)}
 	}
 
@@ -139,7 +151,7 @@ func (p *Permissions) RemoveUserPermissions(username string, permissions []strin
 
 // AvailableUsersPermissionsList return a list of users which weren't explicitly granted any project plan permissions for the given project.
 func (p *Permissions) AvailableUsersPermissionsList(opts PermissionsOpts) ([]User, *http.Response, error) {
-	if !knownResources[opts.Resource] {
+	if !opts.resourceType().Valid() {
 		return nil, nil, &simpleError{fmt.Sprintf("Unknown resource %s", opts.Resource)}
 	}
 
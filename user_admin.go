@@ -0,0 +1,320 @@
+package bamboo
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// adminPageSize is the number of entries requested per page when collecting every entry of an admin listing
+const adminPageSize = 100
+
+// UserAdminService handles communication with the user and group administration methods
+type UserAdminService service
+
+// NewUser holds the details of a local user to create
+type NewUser struct {
+	Name            string `json:"name"`
+	FullName        string `json:"fullName"`
+	Email           string `json:"email"`
+	Password        string `json:"password"`
+	PasswordConfirm string `json:"passwordConfirm"`
+}
+
+// UserPage is a single page of users
+// - Start:      Index of the first user in the page
+// - Limit:      Maximum number of users in the page
+// - IsLastPage: There are no more users after this page
+type UserPage struct {
+	Users      []*User `json:"results"`
+	Start      int     `json:"start"`
+	Limit      int     `json:"limit"`
+	IsLastPage bool    `json:"isLastPage"`
+}
+
+// GroupPage is a single page of groups, see UserPage
+type GroupPage struct {
+	Groups     []*Group `json:"results"`
+	Start      int      `json:"start"`
+	Limit      int      `json:"limit"`
+	IsLastPage bool     `json:"isLastPage"`
+}
+
+// lastPage reports whether a page holding count entries was the last one
+func lastPage(isLastPage bool, count, limit int) bool {
+	return isLastPage || count == 0 || count < limit
+}
+
+// adminListURL builds the URL of an admin listing with an optional filter. A nil page uses the server defaults.
+func adminListURL(u, filter string, page *Pagination) string {
+	values := url.Values{}
+	if filter != "" {
+		values.Set("filter", filter)
+	}
+	if page != nil {
+		values.Set("start", strconv.Itoa(page.Start))
+		if page.Limit > 0 {
+			values.Set("limit", strconv.Itoa(page.Limit))
+		}
+	}
+	if len(values) == 0 {
+		return u
+	}
+	return u + "?" + values.Encode()
+}
+
+// -- Users --
+
+// ListUsers returns a page of the users whose name, full name or email contain filter.
+// Leave filter blank to list every user.
+func (u *UserAdminService) ListUsers(filter string, page *Pagination) (*UserPage, *http.Response, error) {
+	return u.userPage(adminListURL("admin/users", filter, page), "Listing users")
+}
+
+// AllUsers returns every user whose name, full name or email contain filter, requesting as many pages as needed
+func (u *UserAdminService) AllUsers(filter string) ([]*User, error) {
+	users := []*User{}
+	page := &Pagination{Limit: adminPageSize}
+	for {
+		data, _, err := u.ListUsers(filter, page)
+		if err != nil {
+			return nil, err
+		}
+		users = append(users, data.Users...)
+
+		if lastPage(data.IsLastPage, len(data.Users), page.Limit) {
+			return users, nil
+		}
+		page.Start += len(data.Users)
+	}
+}
+
+// CreateUser creates a local user. PasswordConfirm is filled in from Password if left blank.
+func (u *UserAdminService) CreateUser(user *NewUser) (*http.Response, error) {
+	if user == nil || emptyStrings(user.Name, user.Password) {
+		return nil, &simpleError{"A user needs a name and a password"}
+	}
+	if user.PasswordConfirm == "" {
+		confirmed := *user
+		confirmed.PasswordConfirm = user.Password
+		user = &confirmed
+	}
+
+	request, err := u.client.NewRequest(http.MethodPost, "admin/users", user)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := u.client.Do(request, nil)
+	if err != nil {
+		return response, err
+	}
+
+	switch response.StatusCode {
+	case 200, 201, 204:
+		return response, nil
+	case 400:
+		return response, &simpleError{fmt.Sprintf("User %s already exists or its details are invalid", user.Name)}
+	case 401:
+		return response, &simpleError{"You must be an admin to preform this action"}
+	}
+	return response, &simpleError{fmt.Sprintf("Server responded with unexpected return code %d", response.StatusCode)}
+}
+
+// UpdateUser changes the full name and email of the user with the given user's name
+func (u *UserAdminService) UpdateUser(user *User) (*http.Response, error) {
+	if user == nil || user.Name == "" {
+		return nil, &simpleError{"A user name is required"}
+	}
+
+	body := struct {
+		FullName string `json:"fullName"`
+		Email    string `json:"email"`
+	}{user.FullName, user.Email}
+
+	request, err := u.client.NewRequest(http.MethodPut, fmt.Sprintf("admin/users/%s", url.PathEscape(user.Name)), body)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := u.client.Do(request, nil)
+	if err != nil {
+		return response, err
+	}
+
+	return response, adminChangeError(response, "User", user.Name)
+}
+
+// DeleteUser deletes the local user with the given name
+func (u *UserAdminService) DeleteUser(name string) (*http.Response, error) {
+	request, err := u.client.NewRequest(http.MethodDelete, fmt.Sprintf("admin/users/%s", url.PathEscape(name)), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := u.client.Do(request, nil)
+	if err != nil {
+		return response, err
+	}
+
+	return response, adminChangeError(response, "User", name)
+}
+
+// UserGroups returns the names of the groups the given user is a member of
+func (u *UserAdminService) UserGroups(username string) ([]string, error) {
+	groups := []string{}
+	page := &Pagination{Limit: adminPageSize}
+	for {
+		data, _, err := u.groupPage(adminListURL(fmt.Sprintf("admin/users/%s/groups", url.PathEscape(username)), "", page), fmt.Sprintf("Retrieving groups for user %s", username))
+		if err != nil {
+			return nil, err
+		}
+		for _, group := range data.Groups {
+			groups = append(groups, group.Name)
+		}
+
+		if lastPage(data.IsLastPage, len(data.Groups), page.Limit) {
+			return groups, nil
+		}
+		page.Start += len(data.Groups)
+	}
+}
+
+// -- Groups --
+
+// ListGroups returns a page of the groups whose name contains filter. Leave filter blank to list every group.
+func (u *UserAdminService) ListGroups(filter string, page *Pagination) (*GroupPage, *http.Response, error) {
+	return u.groupPage(adminListURL("admin/groups", filter, page), "Listing groups")
+}
+
+// CreateGroup creates a local group with the given name
+func (u *UserAdminService) CreateGroup(name string) (*http.Response, error) {
+	if name == "" {
+		return nil, &simpleError{"A group name is required"}
+	}
+
+	request, err := u.client.NewRequest(http.MethodPost, "admin/groups", &Group{Name: name})
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := u.client.Do(request, nil)
+	if err != nil {
+		return response, err
+	}
+
+	switch response.StatusCode {
+	case 200, 201, 204:
+		return response, nil
+	case 400:
+		return response, &simpleError{fmt.Sprintf("Group %s already exists or its name is invalid", name)}
+	case 401:
+		return response, &simpleError{"You must be an admin to preform this action"}
+	}
+	return response, &simpleError{fmt.Sprintf("Server responded with unexpected return code %d", response.StatusCode)}
+}
+
+// DeleteGroup deletes the local group with the given name
+func (u *UserAdminService) DeleteGroup(name string) (*http.Response, error) {
+	request, err := u.client.NewRequest(http.MethodDelete, fmt.Sprintf("admin/groups/%s", url.PathEscape(name)), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := u.client.Do(request, nil)
+	if err != nil {
+		return response, err
+	}
+
+	return response, adminChangeError(response, "Group", name)
+}
+
+// GroupMembers returns a page of the members of the group whose name, full name or email contain filter
+func (u *UserAdminService) GroupMembers(group, filter string, page *Pagination) (*UserPage, *http.Response, error) {
+	return u.userPage(adminListURL(fmt.Sprintf("admin/groups/%s/more-members", url.PathEscape(group)), filter, page), fmt.Sprintf("Listing members of group %s", group))
+}
+
+// AddGroupMembers adds the users to the group
+func (u *UserAdminService) AddGroupMembers(group string, usernames ...string) (*http.Response, error) {
+	return u.changeGroupMembers(http.MethodPost, fmt.Sprintf("admin/groups/%s/add-users", url.PathEscape(group)), group, usernames)
+}
+
+// RemoveGroupMembers removes the users from the group
+func (u *UserAdminService) RemoveGroupMembers(group string, usernames ...string) (*http.Response, error) {
+	return u.changeGroupMembers(http.MethodDelete, fmt.Sprintf("admin/groups/%s/remove-users", url.PathEscape(group)), group, usernames)
+}
+
+func (u *UserAdminService) changeGroupMembers(method, urlStr, group string, usernames []string) (*http.Response, error) {
+	if len(usernames) == 0 {
+		return nil, &simpleError{"At least one user name is required"}
+	}
+
+	request, err := u.client.NewRequest(method, urlStr, usernames)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := u.client.Do(request, nil)
+	if err != nil {
+		return response, err
+	}
+
+	return response, adminChangeError(response, "Group", group)
+}
+
+func (u *UserAdminService) userPage(urlStr, action string) (*UserPage, *http.Response, error) {
+	request, err := u.client.NewRequest(http.MethodGet, urlStr, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	data := &UserPage{}
+	response, err := u.client.Do(request, data)
+	if err != nil {
+		return nil, response, err
+	}
+
+	if response.StatusCode == 401 {
+		return nil, response, &simpleError{"You must be an admin to access this information"}
+	} else if response.StatusCode != 200 {
+		return nil, response, &simpleError{fmt.Sprintf("%s returned %s", action, response.Status)}
+	}
+
+	return data, response, nil
+}
+
+func (u *UserAdminService) groupPage(urlStr, action string) (*GroupPage, *http.Response, error) {
+	request, err := u.client.NewRequest(http.MethodGet, urlStr, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	data := &GroupPage{}
+	response, err := u.client.Do(request, data)
+	if err != nil {
+		return nil, response, err
+	}
+
+	if response.StatusCode == 401 {
+		return nil, response, &simpleError{"You must be an admin to access this information"}
+	} else if response.StatusCode != 200 {
+		return nil, response, &simpleError{fmt.Sprintf("%s returned %s", action, response.Status)}
+	}
+
+	return data, response, nil
+}
+
+// adminChangeError converts the response of an admin change to the named user or group into an error.
+// nil is returned if the change succeeded.
+func adminChangeError(response *http.Response, kind, name string) error {
+	switch response.StatusCode {
+	case 200, 201, 204:
+		return nil
+	case 401:
+		return &simpleError{"You must be an admin to preform this action"}
+	case 404:
+		return &simpleError{fmt.Sprintf("%s %s doesn't exist", kind, name)}
+	}
+	return &simpleError{fmt.Sprintf("Server responded with unexpected return code %d", response.StatusCode)}
+}
@@ -0,0 +1,154 @@
+package bamboo_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	bamboo "github.com/rcarmstrong/go-bamboo"
+)
+
+func TestListUsers(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(userAdminStub))
+	defer ts.Close()
+
+	client := bamboo.NewSimpleClient(nil, "", "")
+	client.SetURL(ts.URL)
+
+	page, _, err := client.UserAdmin.ListUsers("", &bamboo.Pagination{Start: 0, Limit: 2})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(page.Users))
+	assert.False(t, page.IsLastPage)
+
+	users, err := client.UserAdmin.AllUsers("")
+	assert.NoError(t, err)
+	assert.Equal(t, 3, len(users))
+
+	users, err = client.UserAdmin.AllUsers("car")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(users))
+	assert.Equal(t, "Carol", users[0].FullName)
+}
+
+func TestUserAdministration(t *testing.T) {
+	var calls []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path := strings.TrimPrefix(r.URL.Path, "/rest/api/latest/")
+		body, _ := ioutil.ReadAll(r.Body)
+		calls = append(calls, fmt.Sprintf("%s %s %s", r.Method, path, strings.TrimSpace(string(body))))
+
+		switch {
+		case strings.HasSuffix(path, "/missing"):
+			w.WriteHeader(http.StatusNotFound)
+		case r.Method == http.MethodPost:
+			w.WriteHeader(http.StatusCreated)
+		default:
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	defer ts.Close()
+
+	client := bamboo.NewSimpleClient(nil, "", "")
+	client.SetURL(ts.URL)
+
+	_, err := client.UserAdmin.CreateUser(&bamboo.NewUser{Name: "dave", FullName: "Dave", Email: "dave@example.com", Password: "secret"})
+	assert.NoError(t, err)
+	_, err = client.UserAdmin.CreateUser(&bamboo.NewUser{Name: "dave"})
+	assert.Error(t, err)
+	_, err = client.UserAdmin.UpdateUser(&bamboo.User{Name: "dave", FullName: "David", Email: "david@example.com"})
+	assert.NoError(t, err)
+	_, err = client.UserAdmin.DeleteUser("dave")
+	assert.NoError(t, err)
+	_, err = client.UserAdmin.DeleteUser("missing")
+	assert.Error(t, err)
+
+	_, err = client.UserAdmin.CreateGroup("ops")
+	assert.NoError(t, err)
+	_, err = client.UserAdmin.AddGroupMembers("ops", "alice", "bob")
+	assert.NoError(t, err)
+	_, err = client.UserAdmin.RemoveGroupMembers("ops", "bob")
+	assert.NoError(t, err)
+	_, err = client.UserAdmin.RemoveGroupMembers("ops")
+	assert.Error(t, err)
+	_, err = client.UserAdmin.DeleteGroup("ops")
+	assert.NoError(t, err)
+
+	assert.Equal(t, []string{
+		`POST admin/users {"name":"dave","fullName":"Dave","email":"dave@example.com","password":"secret","passwordConfirm":"secret"}`,
+		`PUT admin/users/dave {"fullName":"David","email":"david@example.com"}`,
+		`DELETE admin/users/dave `,
+		`DELETE admin/users/missing `,
+		`POST admin/groups {"name":"ops"}`,
+		`POST admin/groups/ops/add-users ["alice","bob"]`,
+		`DELETE admin/groups/ops/remove-users ["bob"]`,
+		`DELETE admin/groups/ops `,
+	}, calls)
+}
+
+func TestGroupMembers(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(userAdminStub))
+	defer ts.Close()
+
+	client := bamboo.NewSimpleClient(nil, "", "")
+	client.SetURL(ts.URL)
+
+	members, _, err := client.UserAdmin.GroupMembers("developers", "", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(members.Users))
+
+	groups, _, err := client.UserAdmin.ListGroups("dev", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "developers", groups.Groups[0].Name)
+
+	userGroups, err := client.UserAdmin.UserGroups("alice")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"developers"}, userGroups)
+}
+
+var adminTestUsers = []*bamboo.User{
+	{Name: "alice", FullName: "Alice", Email: "alice@example.com"},
+	{Name: "bob", FullName: "Bob", Email: "bob@example.com"},
+	{Name: "carol", FullName: "Carol", Email: "carol@example.com"},
+}
+
+func userAdminStub(w http.ResponseWriter, r *http.Request) {
+	path := strings.TrimPrefix(r.URL.Path, "/rest/api/latest/")
+	query := r.URL.Query()
+
+	switch path {
+	case "admin/users":
+		users := []*bamboo.User{}
+		for _, user := range adminTestUsers {
+			if strings.Contains(user.Name, query.Get("filter")) {
+				users = append(users, user)
+			}
+		}
+
+		start, _ := strconv.Atoi(query.Get("start"))
+		limit, err := strconv.Atoi(query.Get("limit"))
+		if err != nil {
+			limit = 25
+		}
+		end := start + limit
+		if end > len(users) {
+			end = len(users)
+		}
+
+		json.NewEncoder(w).Encode(&bamboo.UserPage{Users: users[start:end], Start: start, Limit: limit, IsLastPage: end == len(users)})
+	case "admin/groups":
+		fmt.Fprint(w, `{"results":[{"name":"developers"}],"start":0,"limit":25,"isLastPage":true}`)
+	case "admin/groups/developers/more-members":
+		fmt.Fprint(w, `{"results":[{"name":"alice","fullName":"Alice"}],"start":0,"limit":25,"isLastPage":true}`)
+	case "admin/users/alice/groups":
+		fmt.Fprint(w, `{"results":[{"name":"developers"}],"start":0,"limit":100,"isLastPage":true}`)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
@@ -1,10 +1,18 @@
 package bamboo
 
 import (
+	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
 )
 
+// defaultConcurrency is the number of concurrent requests made by methods that fan out over many resources
+const defaultConcurrency = 4
+
 func emptyStrings(strings ...string) bool {
 	for _, s := range strings {
 		if s == "" {
@@ -25,3 +33,120 @@ type Pagination struct {
 	Start int
 	Limit int
 }
+
+// setQuery adds the start-index and max-result parameters to the given query values.
+// A nil Pagination leaves the values untouched so the server defaults are used.
+func (p *Pagination) setQuery(values url.Values) {
+	if p == nil {
+		return
+	}
+	values.Set("start-index", strconv.Itoa(p.Start))
+	if p.Limit > 0 {
+		values.Set("max-result", strconv.Itoa(p.Limit))
+	}
+}
+
+// epochTime decodes the millisecond epoch timestamps returned by the Bamboo API.
+// RFC 3339 strings are accepted as well so marshalled values can be read back.
+type epochTime struct {
+	time.Time
+}
+
+func (t *epochTime) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		t.Time = time.Time{}
+		return nil
+	}
+
+	var millis int64
+	if err := json.Unmarshal(data, &millis); err == nil {
+		t.Time = millisToTime(millis)
+		return nil
+	}
+
+	return json.Unmarshal(data, &t.Time)
+}
+
+func millisToTime(millis int64) time.Time {
+	if millis == 0 {
+		return time.Time{}
+	}
+	return time.Unix(0, millis*int64(time.Millisecond))
+}
+
+// advance moves the page past the count resources that were just returned and reports whether
+// another page should be requested. The server may return fewer resources than asked for, so a short
+// page only ends the listing when the response has no size to go by. metadata may be nil if the
+// response did not include any.
+func (p *Pagination) advance(count int, metadata *CollectionMetadata) bool {
+	p.Start += count
+	if count == 0 {
+		return false
+	}
+	if metadata == nil || metadata.Size == 0 {
+		return count >= p.Limit
+	}
+	return p.Start < metadata.Size
+}
+
+// forEach calls fn for every index in [0, n) using at most limit goroutines at a time.
+// All calls are made even if some fail; the first error encountered is returned.
+func forEach(n, limit int, fn func(i int) error) error {
+	if limit <= 0 {
+		limit = defaultConcurrency
+	}
+
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		firstErr error
+	)
+	sem := make(chan struct{}, limit)
+
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+
+			if err := fn(i); err != nil {
+				mu.Lock()
+				if firstErr == nil {
+					firstErr = err
+				}
+				mu.Unlock()
+			}
+		}(i)
+	}
+
+	wg.Wait()
+	return firstErr
+}
+
+// rateLimiter spaces out requests made from any number of goroutines. A nil rateLimiter never waits.
+type rateLimiter struct {
+	ticker *time.Ticker
+}
+
+// newRateLimiter returns a limiter allowing perSecond requests a second, or nil if perSecond isn't positive
+func newRateLimiter(perSecond float64) *rateLimiter {
+	if perSecond <= 0 {
+		return nil
+	}
+	return &rateLimiter{ticker: time.NewTicker(time.Duration(float64(time.Second) / perSecond))}
+}
+
+func (r *rateLimiter) wait() {
+	if r != nil {
+		<-r.ticker.C
+	}
+}
+
+func (r *rateLimiter) stop() {
+	if r != nil {
+		r.ticker.Stop()
+	}
+}
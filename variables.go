@@ -0,0 +1,68 @@
+package bamboo
+
+// MaskedValue is what the server returns in place of the value of a password variable.
+// Variables whose name contains "password", "secret" or "passphrase" are masked by default.
+const MaskedValue = "********"
+
+// Variable is a single name/value pair defined on a Bamboo entity such as a
+// plan, project or deployment environment. Values of password variables are
+// masked by the server.
+type Variable struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// Masked reports whether the server hid the value of the variable
+func (v *Variable) Masked() bool {
+	return v.Value == MaskedValue
+}
+
+// VariableDiff describes how an actual set of variables differs from a desired one
+// - Missing: Desired variables that are not defined
+// - Changed: Desired variables that are defined with a different value
+// - Extra:   Defined variables that are not desired
+// - Masked:  Desired variables that are defined with a masked value, so could not be compared
+type VariableDiff struct {
+	Missing []*Variable
+	Changed []*Variable
+	Extra   []*Variable
+	Masked  []*Variable
+}
+
+// Empty reports whether the actual variables match the desired ones. Masked variables are
+// assumed to match.
+func (d *VariableDiff) Empty() bool {
+	return len(d.Missing) == 0 && len(d.Changed) == 0 && len(d.Extra) == 0
+}
+
+// DiffVariables compares the actual variables against the desired variables. Password variables
+// come back masked, so rather than always being reported as changed they are listed in Masked.
+func DiffVariables(desired, actual []*Variable) *VariableDiff {
+	diff := &VariableDiff{}
+
+	actualValues := make(map[string]string, len(actual))
+	for _, v := range actual {
+		actualValues[v.Name] = v.Value
+	}
+
+	desiredNames := make(map[string]bool, len(desired))
+	for _, v := range desired {
+		desiredNames[v.Name] = true
+		value, ok := actualValues[v.Name]
+		if !ok {
+			diff.Missing = append(diff.Missing, v)
+		} else if value == MaskedValue {
+			diff.Masked = append(diff.Masked, v)
+		} else if value != v.Value {
+			diff.Changed = append(diff.Changed, v)
+		}
+	}
+
+	for _, v := range actual {
+		if !desiredNames[v.Name] {
+			diff.Extra = append(diff.Extra, v)
+		}
+	}
+
+	return diff
+}